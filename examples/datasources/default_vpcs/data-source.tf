@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo,
+      # and uncomment the version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+# List the default VPC in every region enabled for our account
+data "awsutils_default_vpcs" "all" {
+  regions = ["all"]
+}
+
+output "default_vpc_ids" {
+  value = data.awsutils_default_vpcs.all.vpc_ids
+}
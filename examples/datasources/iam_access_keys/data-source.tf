@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo,
+      # and uncomment the version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+# Find active access keys that are older than 90 days and were not used in the last 30 days
+data "awsutils_iam_access_keys" "stale" {
+  status     = "Active"
+  older_than = 90
+  unused_for = 30
+}
+
+output "stale_access_keys" {
+  value = {
+    for key in data.awsutils_iam_access_keys.stale.access_keys : key.access_key_id => key.user_name
+  }
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo,
+      # and uncomment the version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+# Find the enabled controls of AWS Foundational Security Best Practices with a low severity
+data "awsutils_security_hub_controls" "fsbp_low" {
+  standards_arns = ["arn:aws:securityhub:us-east-1::standards/aws-foundational-security-best-practices/v/1.0.0"]
+  severities     = ["LOW"]
+  status         = "ENABLED"
+}
+
+resource "awsutils_security_hub_control_disablement" "fsbp_low" {
+  for_each = {
+    for control in data.awsutils_security_hub_controls.fsbp_low.controls : control.control_id => control.arn
+  }
+
+  control_arn = each.value
+  reason      = "Low severity findings are not tracked"
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo,
+      # and uncomment the version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+# Summarize the active findings that have not been suppressed or resolved
+data "awsutils_security_hub_findings_summary" "open" {
+  workflow_statuses           = ["NEW", "NOTIFIED"]
+  top_failing_resources_limit = 5
+}
+
+output "open_findings_by_severity" {
+  value = data.awsutils_security_hub_findings_summary.open.by_severity
+}
+
+output "top_failing_resources" {
+  value = data.awsutils_security_hub_findings_summary.open.top_failing_resources
+}
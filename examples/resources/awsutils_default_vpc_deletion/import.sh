@@ -0,0 +1,7 @@
+# Adopt the provider's region, for a resource without `regions`
+terraform import awsutils_default_vpc_deletion.default us-east-1
+
+# Adopt the configured `regions`, as a comma-separated list. A single region is followed by a comma.
+terraform import awsutils_default_vpc_deletion.all all
+terraform import awsutils_default_vpc_deletion.some us-east-2,us-west-2
+terraform import awsutils_default_vpc_deletion.one us-west-2,
@@ -17,3 +17,23 @@ provider "awsutils" {
 # Delete the default VPC in our account/region
 resource "awsutils_default_vpc_deletion" "default" {
 }
+
+# Delete the default VPC in every region enabled for our account
+resource "awsutils_default_vpc_deletion" "all" {
+  regions = ["all"]
+}
+
+# Delete the default VPC in a sandbox account, and create a new one when the baseline is destroyed
+resource "awsutils_default_vpc_deletion" "sandbox" {
+  restore_on_destroy = true
+}
+
+# Report what would be deleted in every region without deleting anything
+resource "awsutils_default_vpc_deletion" "preview" {
+  regions = ["all"]
+  dry_run = true
+}
+
+output "blocking_resources" {
+  value = awsutils_default_vpc_deletion.preview.blocking_resources
+}
@@ -0,0 +1,5 @@
+# Import an existing access key, which never expires
+terraform import awsutils_expiring_iam_access_key.test AKIA1234EXAMPLE1234
+
+# Import an existing access key that expires 90 days after it was created, and is rotated right away when it is older
+terraform import awsutils_expiring_iam_access_key.test AKIA1234EXAMPLE1234/7776000
@@ -24,6 +24,56 @@ resource "awsutils_expiring_iam_access_key" "test" {
   max_age = 60 * 60 * 24 * 30 # 30 days
 }
 
+# Rotate the access key every 30 days, and keep the previous access key, deactivated, for 7 more days
+resource "awsutils_expiring_iam_access_key" "rotating" {
+  user                  = aws_iam_user.test.name
+  max_age               = 60 * 60 * 24 * 30 # 30 days
+  rotation_grace_period = 60 * 60 * 24 * 7  # 7 days
+  previous_key_status   = "Inactive"
+}
+
+# Refuse to delete the access key if it was used in the last 24 hours, and deactivate it regardless
+resource "awsutils_expiring_iam_access_key" "protected" {
+  user                       = aws_iam_user.test.name
+  max_age                    = 60 * 60 * 24 * 30 # 30 days
+  deactivate_before_delete   = true
+  deletion_protection_window = 60 * 60 * 24 # 24 hours
+}
+
+# Keep the secret out of the state, and publish each new access key to Secrets Manager
+resource "awsutils_expiring_iam_access_key" "published" {
+  user                  = aws_iam_user.test.name
+  max_age               = 60 * 60 * 24 * 30 # 30 days
+  rotation_grace_period = 60 * 60 * 24 * 7  # 7 days
+
+  destination {
+    type = "secretsmanager"
+    name = "iam/test/access-key"
+  }
+}
+
+# Encrypt the secret so that any of the team members can decrypt it with age
+resource "awsutils_expiring_iam_access_key" "encrypted" {
+  user    = aws_iam_user.test.name
+  max_age = 60 * 60 * 24 * 30 # 30 days
+
+  age_recipients = [
+    "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+  ]
+}
+
+# Derive the SES SMTP passwords of the mail relays in several regions
+resource "awsutils_expiring_iam_access_key" "smtp" {
+  user             = aws_iam_user.test.name
+  max_age          = 60 * 60 * 24 * 90 # 90 days
+  ses_smtp_regions = ["us-east-1", "eu-west-1", "ap-southeast-2"]
+}
+
+output "encrypted_secret" {
+  # Decrypt with: terraform output -raw encrypted_secret | base64 --decode | age --decrypt --identity key.txt
+  value = awsutils_expiring_iam_access_key.encrypted.encrypted_secret
+}
+
 output "id" {
   value = awsutils_expiring_iam_access_key.test.id
 }
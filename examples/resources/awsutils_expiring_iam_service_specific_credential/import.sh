@@ -0,0 +1,6 @@
+# Import an existing service-specific credential, which never expires
+terraform import awsutils_expiring_iam_service_specific_credential.codecommit codecommit.amazonaws.com:test:ACCA1234EXAMPLE1234
+
+# Import an existing service-specific credential that expires 90 days after it was created, and is replaced right away
+# when it is older
+terraform import awsutils_expiring_iam_service_specific_credential.codecommit codecommit.amazonaws.com:test:ACCA1234EXAMPLE1234/7776000
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "aws_iam_user" "test" {
+  name = "test"
+  path = "/test/"
+}
+
+# HTTPS Git credentials for CodeCommit, replaced every 90 days
+resource "awsutils_expiring_iam_service_specific_credential" "codecommit" {
+  user         = aws_iam_user.test.name
+  service_name = "codecommit.amazonaws.com"
+  max_age      = 60 * 60 * 24 * 90 # 90 days
+}
+
+output "service_user_name" {
+  value = awsutils_expiring_iam_service_specific_credential.codecommit.service_user_name
+}
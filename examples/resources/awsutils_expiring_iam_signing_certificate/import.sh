@@ -0,0 +1,5 @@
+# Import an existing signing certificate, which never expires
+terraform import awsutils_expiring_iam_signing_certificate.default test:ABCDEFGHIJKLMNOPQRSTUVWXYZ123456
+
+# Import an existing signing certificate that expires 90 days after it was uploaded, and is replaced right away when it is older
+terraform import awsutils_expiring_iam_signing_certificate.default test:ABCDEFGHIJKLMNOPQRSTUVWXYZ123456/7776000
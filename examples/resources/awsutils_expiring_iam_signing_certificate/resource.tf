@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "aws_iam_user" "test" {
+  name = "test"
+  path = "/test/"
+}
+
+# Generate a new key and self-signed signing certificate every 90 days
+resource "awsutils_expiring_iam_signing_certificate" "default" {
+  user    = aws_iam_user.test.name
+  max_age = 60 * 60 * 24 * 90 # 90 days
+}
+
+output "certificate_id" {
+  value = awsutils_expiring_iam_signing_certificate.default.certificate_id
+}
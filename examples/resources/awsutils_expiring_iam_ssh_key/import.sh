@@ -0,0 +1,5 @@
+# Import an existing SSH public key, which never expires
+terraform import awsutils_expiring_iam_ssh_key.codecommit test:APKA1234EXAMPLE1234
+
+# Import an existing SSH public key that expires 90 days after it was uploaded, and is replaced right away when it is older
+terraform import awsutils_expiring_iam_ssh_key.codecommit test:APKA1234EXAMPLE1234/7776000
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "aws_iam_user" "test" {
+  name = "test"
+  path = "/test/"
+}
+
+# Generate a new SSH key pair for CodeCommit every 90 days
+resource "awsutils_expiring_iam_ssh_key" "codecommit" {
+  user    = aws_iam_user.test.name
+  max_age = 60 * 60 * 24 * 90 # 90 days
+}
+
+output "ssh_public_key_id" {
+  value = awsutils_expiring_iam_ssh_key.codecommit.ssh_public_key_id
+}
@@ -0,0 +1,6 @@
+# Import an existing login profile, whose password never expires
+terraform import awsutils_expiring_iam_user_login_profile.test test
+
+# Import an existing login profile whose password expires 90 days after the login profile was created, and is rotated
+# right away when it is older
+terraform import awsutils_expiring_iam_user_login_profile.test test/7776000
@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "aws_iam_user" "test" {
+  name          = "test"
+  path          = "/test/"
+  force_destroy = true
+}
+
+# Generate a new console password every 90 days, encrypted with the PGP key of the user
+resource "awsutils_expiring_iam_user_login_profile" "test" {
+  user                    = aws_iam_user.test.name
+  pgp_key                 = "keybase:test"
+  password_length         = 24
+  password_reset_required = true
+  max_age                 = 60 * 60 * 24 * 90 # 90 days
+}
+
+output "encrypted_password" {
+  value = awsutils_expiring_iam_user_login_profile.test.encrypted_password
+}
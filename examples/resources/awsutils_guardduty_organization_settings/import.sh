@@ -0,0 +1 @@
+terraform import awsutils_guardduty_organization_settings.default 42bd3eab69b96663418094bb59397d1f
@@ -19,5 +19,11 @@ resource "awsutils_security_hub_control_disablement" "default" {
   reason      = "Global Resources are not evaluated in this region"
 }
 
+# With consolidated control findings, disable a security control in every enabled standard at once
+resource "awsutils_security_hub_control_disablement" "ec2_default_sg" {
+  control_id = "EC2.2"
+  reason     = "Default security groups are removed by the account baseline"
+}
+
 data "aws_region" "this" {}
 data "aws_caller_identity" "this" {}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "awsutils_security_hub_control_disablements" "baseline" {
+  controls = {
+    "CloudTrail.5" = "CloudTrail is centralized in the audit account"
+    "EC2.2"        = "Default security groups are removed by the account baseline"
+    "IAM.6"        = "Hardware MFA for the root user is managed by the organization"
+    "S3.11"        = ""
+  }
+}
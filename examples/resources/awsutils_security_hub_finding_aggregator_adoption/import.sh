@@ -0,0 +1 @@
+terraform import awsutils_security_hub_finding_aggregator_adoption.default arn:aws:securityhub:us-east-1:123456789012:finding-aggregator/123e4567-e89b-12d3-a456-426652340000
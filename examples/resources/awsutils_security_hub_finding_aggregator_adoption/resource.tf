@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "awsutils_security_hub_finding_aggregator_adoption" "default" {
+  linking_mode      = "SPECIFIED_REGIONS"
+  specified_regions = ["us-east-2", "us-west-2", "eu-west-1"]
+
+  # The aggregator is managed by AWS Control Tower
+  retain_on_destroy = true
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "awsutils_security_hub_finding_suppression" "sandbox_public_buckets" {
+  filter {
+    product_names       = ["Security Hub"]
+    generator_ids       = ["security-control/S3.8"]
+    compliance_statuses = ["FAILED"]
+
+    resource_tags = {
+      Environment = "sandbox"
+    }
+  }
+
+  note = "Public access is accepted for sandbox buckets"
+}
@@ -15,6 +15,16 @@ provider "awsutils" {
 }
 
 resource "awsutils_security_hub_organization_settings" "default" {
+  member_accounts                 = ["111111111111", "222222222222"]
+  auto_enable_new_accounts        = true
+  auto_enable_standards           = "DEFAULT"
+  disassociate_members_on_destroy = true
+}
+
+# Configure the member accounts with the configuration policies of the delegated administrator instead
+resource "awsutils_security_hub_organization_settings" "central" {
   member_accounts          = ["111111111111", "222222222222"]
-  auto_enable_new_accounts = true
+  configuration_type       = "CENTRAL"
+  auto_enable_new_accounts = false
+  auto_enable_standards    = "NONE"
 }
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+resource "awsutils_security_hub_product_subscriptions" "default" {
+  products = [
+    "guardduty",
+    "inspector",
+    "macie",
+    "config",
+    "access-analyzer",
+    "arn:aws:securityhub:us-east-1:956882708938:product/crowdstrike/crowdstrike-falcon",
+  ]
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    awsutils = {
+      source = "cloudposse/awsutils"
+      # For local development,
+      # install the provider on local computer by running `make install` from the root of the repo, and uncomment the 
+      # version below
+      # version = "9999.99.99"
+    }
+  }
+}
+
+provider "awsutils" {
+  region = "us-east-1"
+}
+
+data "aws_region" "this" {}
+data "aws_partition" "this" {}
+
+# Run from the Security Hub Administrator account
+resource "awsutils_security_hub_standards_subscriptions" "default" {
+  member_accounts = ["111111111111", "222222222222", "333333333333"]
+
+  standards_arns = [
+    "arn:${data.aws_partition.this.partition}:securityhub:${data.aws_region.this.name}::standards/aws-foundational-security-best-practices/v/1.0.0",
+    "arn:${data.aws_partition.this.partition}:securityhub:${data.aws_region.this.name}::standards/cis-aws-foundations-benchmark/v/1.4.0",
+  ]
+}
+
+output "account_standards" {
+  value = awsutils_security_hub_standards_subscriptions.default.account_standards
+}
@@ -185,7 +185,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/lookoutforvision"
 	"github.com/aws/aws-sdk-go/service/lookoutmetrics"
 	"github.com/aws/aws-sdk-go/service/machinelearning"
-	"github.com/aws/aws-sdk-go/service/macie"
 	"github.com/aws/aws-sdk-go/service/macie2"
 	"github.com/aws/aws-sdk-go/service/managedblockchain"
 	"github.com/aws/aws-sdk-go/service/managedgrafana"
@@ -502,7 +501,6 @@ type AWSClient struct {
 	MTurkConn                        *mturk.MTurk
 	MWAAConn                         *mwaa.MWAA
 	MachineLearningConn              *machinelearning.MachineLearning
-	MacieConn                        *macie.Macie
 	Macie2Conn                       *macie2.Macie2
 	ManagedBlockchainConn            *managedblockchain.ManagedBlockchain
 	MarketplaceCatalogConn           *marketplacecatalog.MarketplaceCatalog
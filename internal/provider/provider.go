@@ -248,15 +248,28 @@ func New(_ context.Context) (*schema.Provider, error) {
 		DataSourcesMap: map[string]*schema.Resource{
 			"awsutils_ec2_client_vpn_export_client_config": ec2.DataSourceEC2ExportClientVpnClientConfiguration(),
 			"awsutils_caller_identity":                     sts.DataSourceCallerIdentity(),
+			"awsutils_default_vpcs":                        ec2.DataSourceDefaultVpcs(),
+			"awsutils_iam_access_keys":                     iam.DataSourceAccessKeys(),
+			"awsutils_security_hub_controls":               securityhub.DataSourceSecurityHubControls(),
+			"awsutils_security_hub_findings_summary":       securityhub.DataSourceSecurityHubFindingsSummary(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"awsutils_default_vpc_deletion":               ec2.ResourceDefaultVpcDeletion(),
-			"awsutils_expiring_iam_access_key":            iam.ResourceExpiringAccessKey(),
-			"awsutils_guardduty_organization_settings":    guardduty.ResourceAwsUtilsGuardDutyOrganizationSettings(),
-			"awsutils_macie2_organization_settings":       macie2.ResourceAwsUtilsMacie2OrganizationSettings(),
-			"awsutils_security_hub_control_disablement":   securityhub.ResourceSecurityHubControlDisablement(),
-			"awsutils_security_hub_organization_settings": securityhub.ResourceSecurityHubOrganizationSettings(),
+			"awsutils_default_vpc_deletion":                     ec2.ResourceDefaultVpcDeletion(),
+			"awsutils_expiring_iam_access_key":                  iam.ResourceExpiringAccessKey(),
+			"awsutils_expiring_iam_service_specific_credential": iam.ResourceExpiringServiceSpecificCredential(),
+			"awsutils_expiring_iam_user_login_profile":          iam.ResourceExpiringLoginProfile(),
+			"awsutils_expiring_iam_ssh_key":                     iam.ResourceExpiringSSHKey(),
+			"awsutils_expiring_iam_signing_certificate":         iam.ResourceExpiringSigningCertificate(),
+			"awsutils_guardduty_organization_settings":          guardduty.ResourceAwsUtilsGuardDutyOrganizationSettings(),
+			"awsutils_macie2_organization_settings":             macie2.ResourceAwsUtilsMacie2OrganizationSettings(),
+			"awsutils_security_hub_control_disablement":         securityhub.ResourceSecurityHubControlDisablement(),
+			"awsutils_security_hub_control_disablements":        securityhub.ResourceSecurityHubControlDisablements(),
+			"awsutils_security_hub_finding_aggregator_adoption": securityhub.ResourceSecurityHubFindingAggregatorAdoption(),
+			"awsutils_security_hub_finding_suppression":         securityhub.ResourceSecurityHubFindingSuppression(),
+			"awsutils_security_hub_organization_settings":       securityhub.ResourceSecurityHubOrganizationSettings(),
+			"awsutils_security_hub_product_subscriptions":       securityhub.ResourceSecurityHubProductSubscriptions(),
+			"awsutils_security_hub_standards_subscriptions":     securityhub.ResourceSecurityHubStandardsSubscriptions(),
 		},
 	}
 
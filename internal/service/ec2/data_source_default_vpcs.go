@@ -0,0 +1,189 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceDefaultVpcs() *schema.Resource {
+	return &schema.Resource{
+		Description: `Lists the default VPC in the configured region, or in each of the regions listed in ` + "`regions`" + `, along
+with its Subnets, Internet Gateway and the number of Network Interfaces in it.
+
+Use this data source to audit which regions still have a default VPC before using ` + "`awsutils_default_vpc_deletion`" + `.`,
+		Read:          dataSourceDefaultVpcsRead,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"regions": {
+				Description: "A list of regions to inspect. Use `[\"all\"]` to inspect every region enabled for the account. " +
+					"When omitted, only the provider's configured region is inspected.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.NoZeroValues},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"default_vpcs": {
+				Description: "The default VPCs found, ordered by region. Regions without a default VPC are omitted.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr_block": {
+							Description: "The primary IPv4 CIDR block of the default VPC.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"internet_gateway_id": {
+							Description: "The ID of the Internet Gateway attached to the default VPC, if any.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"network_interface_count": {
+							Description: "The number of Network Interfaces in the default VPC.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"region": {
+							Description: "The region of the default VPC.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"subnet_ids": {
+							Description: "The IDs of the Subnets in the default VPC.",
+							Type:        schema.TypeList,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Computed:    true,
+						},
+						"vpc_id": {
+							Description: "The ID of the default VPC.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"vpc_ids": {
+				Description: "A map of region to the ID of the default VPC in that region. Regions without a default VPC are omitted.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceDefaultVpcsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*conns.AWSClient)
+	regions := []string{client.Region}
+
+	if v, ok := d.GetOk("regions"); ok && v.(*schema.Set).Len() > 0 {
+		var err error
+
+		regions, err = expandDefaultVpcDeletionRegions(client.EC2Conn, flex.ExpandStringSliceofPointers(flex.ExpandStringSet(v.(*schema.Set))))
+		if err != nil {
+			return err
+		}
+	}
+
+	var mu sync.Mutex
+	defaultVpcs := make(map[string]map[string]interface{}, len(regions))
+
+	err := forEachRegion(regions, func(region string) error {
+		defaultVpc, err := findDefaultVpcInventory(regionalEC2Conn(meta, region))
+		if err != nil || defaultVpc == nil {
+			return err
+		}
+
+		defaultVpc["region"] = region
+
+		mu.Lock()
+		defer mu.Unlock()
+		defaultVpcs[region] = defaultVpc
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading default VPCs: %w", err)
+	}
+
+	found := make([]string, 0, len(defaultVpcs))
+	for region := range defaultVpcs {
+		found = append(found, region)
+	}
+	sort.Strings(found)
+
+	tfList := make([]interface{}, 0, len(found))
+	vpcIDs := make(map[string]string, len(found))
+
+	for _, region := range found {
+		tfList = append(tfList, defaultVpcs[region])
+		vpcIDs[region] = defaultVpcs[region]["vpc_id"].(string)
+	}
+
+	d.SetId(client.AccountID)
+
+	if err := d.Set("default_vpcs", tfList); err != nil {
+		return fmt.Errorf("error setting default_vpcs: %w", err)
+	}
+
+	if err := d.Set("vpc_ids", vpcIDs); err != nil {
+		return fmt.Errorf("error setting vpc_ids: %w", err)
+	}
+
+	return nil
+}
+
+// findDefaultVpcInventory looks up the default VPC and its child resources. When not found, returns nil and potentially an API error.
+func findDefaultVpcInventory(conn *ec2.EC2) (map[string]interface{}, error) {
+	vpc, err := FindDefaultVpc(conn)
+	if err != nil || vpc == nil {
+		return nil, err
+	}
+
+	vpcID := aws.StringValue(vpc.VpcId)
+
+	subnets, err := FindSubnetsForVPC(conn, vpcID)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Subnets for VPC (%s): %w", vpcID, err)
+	}
+
+	subnetIDs := make([]string, 0, len(subnets))
+	for _, v := range subnets {
+		subnetIDs = append(subnetIDs, aws.StringValue(v.SubnetId))
+	}
+	sort.Strings(subnetIDs)
+
+	internetGateway, err := FindInternetGatewayForVPC(conn, vpcID)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Internet Gateway for VPC (%s): %w", vpcID, err)
+	}
+
+	var internetGatewayID string
+	if internetGateway != nil {
+		internetGatewayID = aws.StringValue(internetGateway.InternetGatewayId)
+	}
+
+	networkInterfaces, err := FindNetworkInterfaces(conn, &ec2.DescribeNetworkInterfacesInput{
+		Filters: BuildAttributeFilterList(map[string]string{"vpc-id": vpcID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Network Interfaces for VPC (%s): %w", vpcID, err)
+	}
+
+	return map[string]interface{}{
+		"cidr_block":              aws.StringValue(vpc.CidrBlock),
+		"internet_gateway_id":     internetGatewayID,
+		"network_interface_count": len(networkInterfaces),
+		"subnet_ids":              subnetIDs,
+		"vpc_id":                  vpcID,
+	}, nil
+}
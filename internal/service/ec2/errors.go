@@ -13,6 +13,7 @@ const (
 	errCodeAuthFailure                                    = "AuthFailure"
 	errCodeClientInvalidHostIDNotFound                    = "Client.InvalidHostID.NotFound"
 	ErrCodeDefaultSubnetAlreadyExistsInAvailabilityZone   = "DefaultSubnetAlreadyExistsInAvailabilityZone"
+	errCodeDefaultVpcAlreadyExists                        = "DefaultVpcAlreadyExists"
 	errCodeDependencyViolation                            = "DependencyViolation"
 	errCodeGatewayNotAttached                             = "Gateway.NotAttached"
 	errCodeIncorrectState                                 = "IncorrectState"
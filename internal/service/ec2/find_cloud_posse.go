@@ -98,3 +98,34 @@ func FindDefaultVpc(conn *ec2.EC2) (*ec2.Vpc, error) {
 
 	return nil, nil
 }
+
+// FindEnabledRegionNames looks up the names of all Regions that are enabled for the account, including opted-in Regions.
+func FindEnabledRegionNames(conn *ec2.EC2) ([]string, error) {
+	input := &ec2.DescribeRegionsInput{
+		Filters: []*ec2.Filter{
+			NewFilter("opt-in-status", []string{"opt-in-not-required", "opted-in"}),
+		},
+	}
+
+	output, err := conn.DescribeRegions(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return nil, nil
+	}
+
+	var names []string
+
+	for _, region := range output.Regions {
+		if region == nil {
+			continue
+		}
+
+		names = append(names, aws.StringValue(region.RegionName))
+	}
+
+	return names, nil
+}
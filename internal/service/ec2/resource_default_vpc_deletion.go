@@ -1,21 +1,46 @@
 package ec2
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
+	"github.com/google/uuid"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const noDefaultVPC string = "no-default-vpc-found"
 
+const (
+	// allRegions may be given as the only element of `regions` to act on every Region enabled for the account.
+	allRegions = "all"
+
+	// defaultVpcDeletionMaxConcurrency bounds the number of Regions that are processed at the same time.
+	defaultVpcDeletionMaxConcurrency = 5
+
+	// defaultVpcRestoreTimeout is how long to wait for a restored default VPC to become available.
+	defaultVpcRestoreTimeout = 10 * time.Minute
+)
+
 func ResourceDefaultVpcDeletion() *schema.Resource {
 	return &schema.Resource{
-		Description: `Deletes the default VPC along with the child resources of the VPC including Subnets, Route Tables, NACLs and Internet 
-Gateways in the configured region.
+		Description: `Deletes the default VPC along with the child resources of the VPC including Subnets, Route Tables, NACLs, Internet 
+Gateways, Egress-only Internet Gateways, NAT Gateways, VPC Endpoints, VPC Peering Connections, Security Groups, 
+detached Network Interfaces and the DHCP Options Set in the configured region, or in each of the regions listed in 
+` + "`regions`" + `. Child resources are deleted in dependency order, and the error reports the object that blocked deletion.
+The DHCP Options Set that AWS creates for the default VPCs of a region is kept, as the VPCs created later use it.
 		
 Best-practices call for not using the default VPC, but rather, creating a new set of VPCs as necessary. AWS Security 
 Hub will flag the default VPCs as non-compliant if they aren't configured with best-practices. Rather than jumping 
@@ -24,130 +49,628 @@ Terraform Provider, so this resource is necessary.
 		
 Please note that applying this resource is destructive and nonreversible. This resource is unusual as it will 
 **DELETE** infrastructure when ` + "`terraform apply`" + ` is run rather than creating it. This is a permanent 
-deletion and nothing will be restored when ` + "`terraform destroy`" + ` is run. `,
+deletion and nothing will be restored when ` + "`terraform destroy`" + ` is run, unless ` + "`restore_on_destroy`" + ` is set. 
+
+As a safeguard, the default VPC is not deleted when it contains instances, network interfaces not managed by AWS or 
+resources that AWS does not create as part of a default VPC, unless ` + "`force`" + ` is set, in which case its instances 
+are terminated and its network interfaces deleted too. Use ` + "`dry_run`" + ` to review what would be deleted without 
+deleting anything.
+
+If a default VPC is recreated (for example with ` + "`aws ec2 create-default-vpc`" + `) after it was deleted, it is reported 
+in ` + "`recreated_vpc_ids`" + ` and the next ` + "`terraform apply`" + ` deletes it again. Accounts whose default VPCs were 
+deleted by other means can be adopted with ` + "`terraform import`" + `, using the provider's region as the ID, or the 
+comma-separated list of the configured ` + "`regions`" + `, followed by a comma when there is only one.`,
 		Create:        resourceDefaultVpcDeletionCreate,
 		Read:          resourceDefaultVpcDeletionRead,
+		Update:        resourceDefaultVpcDeletionUpdate,
 		Delete:        resourceDefaultVpcDeletionDelete,
 		SchemaVersion: 1,
+
+		CustomizeDiff: resourceDefaultVpcDeletionDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceDefaultVpcDeletionImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of the VPC that was deleted.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"regions": {
+				Description: "A list of regions in which to delete the default VPC. Use `[\"all\"]` to delete the default VPC in " +
+					"every region enabled for the account. When omitted, only the provider's configured region is processed.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.NoZeroValues},
+				Set:      schema.HashString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"dry_run": {
+				Description: "When set, inspects the default VPC and reports what would be deleted in `planned_deletions` and " +
+					"`blocking_resources` without deleting anything.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"force": {
+				Description: "Delete the default VPC even when it contains instances, network interfaces not managed by AWS or " +
+					"resources that are not part of a default VPC. These are listed in `blocking_resources`. The instances are " +
+					"terminated and the network interfaces detached and deleted before the rest of the VPC.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"restore_on_destroy": {
+				Description: "Create a new default VPC, using EC2 `CreateDefaultVpc`, in each region where this resource deleted one " +
+					"when the resource is destroyed. The original default VPC and its child resources are not restored.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"planned_deletions": {
+				Description: "The objects deleted along with the default VPC, or that would be deleted when `dry_run` is set, in deletion order. " +
+					"Entries are prefixed with their region when `regions` is configured.",
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"blocking_resources": {
+				Description: "The objects found in the default VPC that prevent its deletion unless `force` is set. " +
+					"Entries are prefixed with their region when `regions` is configured.",
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"deleted_vpc_ids": {
+				Description: "A map of region to the ID of the default VPC that was deleted in that region. Only set when `regions` is configured.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"deleted_vpc_cidr_blocks": {
+				Description: "A map of region to the CIDR block of the default VPC that was deleted in that region. Only set when `regions` is configured.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"region": {
+				Description: "The region in which the default VPC was deleted. Only set when `regions` is not configured.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"cidr_block": {
+				Description: "The CIDR block of the default VPC that was deleted. Only set when `regions` is not configured.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"recreated_vpc_ids": {
+				Description: "A map of region to the ID of a default VPC that was created again after the deletion. " +
+					"Any entry causes the resource to be replaced, which deletes the recreated default VPCs.",
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
 
 func resourceDefaultVpcDeletionCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*conns.AWSClient).EC2Conn
-	var vpc *ec2.Vpc
+	if v, ok := d.GetOk("regions"); ok && v.(*schema.Set).Len() > 0 {
+		return resourceDefaultVpcDeletionCreateMultiRegion(d, meta, flex.ExpandStringSliceofPointers(flex.ExpandStringSet(v.(*schema.Set))))
+	}
 
-	vpc, err := FindDefaultVpc(conn)
+	client := meta.(*conns.AWSClient)
+
+	result, err := deleteDefaultVpc(client.EC2Conn, d.Get("dry_run").(bool), d.Get("force").(bool))
 	if err != nil {
 		return err
 	}
 
-	if vpc == nil {
-		d.SetId(noDefaultVPC)
-		return resourceDefaultVpcDeletionRead(d, meta)
+	d.SetId(result.VpcID)
+	d.Set("region", client.Region)
+	d.Set("cidr_block", result.CidrBlock)
+
+	if err := setDefaultVpcDeletionResults(d, map[string]*defaultVpcDeletionResult{"": result}); err != nil {
+		return err
 	}
 
-	vpcid := aws.StringValue(vpc.VpcId)
+	// The default VPC was just deleted, so do not look for it again right away: DescribeVpcs is eventually
+	// consistent and may still return it.
+	return d.Set("recreated_vpc_ids", map[string]string{})
+}
 
-	if err = deleteInternetGateway(conn, vpcid); err != nil {
+func resourceDefaultVpcDeletionCreateMultiRegion(d *schema.ResourceData, meta interface{}, configuredRegions []string) error {
+	regions, err := expandDefaultVpcDeletionRegions(meta.(*conns.AWSClient).EC2Conn, configuredRegions)
+	if err != nil {
 		return err
 	}
 
-	if err = deleteSubnets(conn, vpcid); err != nil {
+	dryRun := d.Get("dry_run").(bool)
+	results, err := deleteDefaultVpcsInRegions(meta, regions, dryRun, d.Get("force").(bool))
+
+	// Record whatever was deleted, even on partial failure, so that the progress is not lost.
+	d.SetId(uuid.New().String())
+	if err := setDefaultVpcDeletionResults(d, results); err != nil {
 		return err
 	}
 
-	if err = deleteVpc(conn, vpcid); err != nil {
-		return err
+	if !dryRun {
+		deletedVpcIDs := make(map[string]string, len(results))
+		deletedVpcCidrBlocks := make(map[string]string, len(results))
+		for region, result := range results {
+			deletedVpcIDs[region] = result.VpcID
+			if result.CidrBlock != "" {
+				deletedVpcCidrBlocks[region] = result.CidrBlock
+			}
+		}
+
+		if err := d.Set("deleted_vpc_ids", deletedVpcIDs); err != nil {
+			return fmt.Errorf("error setting deleted_vpc_ids: %w", err)
+		}
+
+		if err := d.Set("deleted_vpc_cidr_blocks", deletedVpcCidrBlocks); err != nil {
+			return fmt.Errorf("error setting deleted_vpc_cidr_blocks: %w", err)
+		}
 	}
 
-	d.SetId(vpcid)
+	if err := d.Set("recreated_vpc_ids", map[string]string{}); err != nil {
+		return fmt.Errorf("error setting recreated_vpc_ids: %w", err)
+	}
 
-	return resourceDefaultVpcDeletionRead(d, meta)
+	return err
 }
 
 func resourceDefaultVpcDeletionRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*conns.AWSClient).EC2Conn
-	var vpc *ec2.Vpc
+	if d.Get("dry_run").(bool) {
+		return resourceDefaultVpcDeletionReadDryRun(d, meta)
+	}
 
-	vpc, err := FindDefaultVpc(conn)
+	var regions []string
+
+	if v, ok := d.GetOk("regions"); ok && v.(*schema.Set).Len() > 0 {
+		for region := range d.Get("deleted_vpc_ids").(map[string]interface{}) {
+			regions = append(regions, region)
+		}
+	} else {
+		region := d.Get("region").(string)
+		if region == "" {
+			// Resources created before the region was recorded were always created in the provider's region.
+			region = meta.(*conns.AWSClient).Region
+			d.Set("region", region)
+		}
+
+		regions = []string{region}
+	}
+
+	recreatedVpcIDs, err := findRecreatedDefaultVpcs(meta, regions)
 	if err != nil {
 		return err
 	}
 
-	if !d.IsNewResource() && vpc != nil {
-		d.SetId("")
+	if err := d.Set("recreated_vpc_ids", recreatedVpcIDs); err != nil {
+		return fmt.Errorf("error setting recreated_vpc_ids: %w", err)
 	}
 
 	return nil
 }
 
+// findRecreatedDefaultVpcs returns a map of region to the ID of the default VPC found in that region. A default VPC
+// found in a region where it was deleted must have been created again.
+func findRecreatedDefaultVpcs(meta interface{}, regions []string) (map[string]string, error) {
+	var mu sync.Mutex
+	recreatedVpcIDs := make(map[string]string)
+
+	err := forEachRegion(regions, func(region string) error {
+		vpc, err := FindDefaultVpc(regionalEC2Conn(meta, region))
+		if err != nil || vpc == nil {
+			return err
+		}
+
+		log.Printf("[WARN] Default VPC (%s) found in region (%s), it will be deleted again", aws.StringValue(vpc.VpcId), region)
+
+		mu.Lock()
+		defer mu.Unlock()
+		recreatedVpcIDs[region] = aws.StringValue(vpc.VpcId)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for default VPCs: %w", err)
+	}
+
+	return recreatedVpcIDs, nil
+}
+
+// resourceDefaultVpcDeletionReadDryRun refreshes the report of what would be deleted. Nothing has been deleted
+// in dry run mode, so finding a default VPC is expected and does not remove the resource from state.
+func resourceDefaultVpcDeletionReadDryRun(d *schema.ResourceData, meta interface{}) error {
+	if d.IsNewResource() {
+		return nil
+	}
+
+	force := d.Get("force").(bool)
+
+	if v, ok := d.GetOk("regions"); ok && v.(*schema.Set).Len() > 0 {
+		regions, err := expandDefaultVpcDeletionRegions(meta.(*conns.AWSClient).EC2Conn, flex.ExpandStringSliceofPointers(flex.ExpandStringSet(v.(*schema.Set))))
+		if err != nil {
+			return err
+		}
+
+		results, err := deleteDefaultVpcsInRegions(meta, regions, true, force)
+		if err != nil {
+			return err
+		}
+
+		return setDefaultVpcDeletionResults(d, results)
+	}
+
+	result, err := deleteDefaultVpc(meta.(*conns.AWSClient).EC2Conn, true, force)
+	if err != nil {
+		return err
+	}
+
+	return setDefaultVpcDeletionResults(d, map[string]*defaultVpcDeletionResult{"": result})
+}
+
+// resourceDefaultVpcDeletionDiff plans the replacement of the resource, and with it the deletion, when a default VPC
+// was created again in a region where it had been deleted.
+func resourceDefaultVpcDeletionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if v, ok := d.GetOk("recreated_vpc_ids"); ok && len(v.(map[string]interface{})) > 0 {
+		return d.SetNewComputed("recreated_vpc_ids")
+	}
+
+	return nil
+}
+
+// resourceDefaultVpcDeletionImport adopts regions whose default VPC was already deleted. The ID is either the
+// provider's region, for a resource without `regions`, or the comma-separated list of the configured `regions`.
+// A default VPC that still exists in any of them is reported by Read and deleted by the next apply.
+func resourceDefaultVpcDeletionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*conns.AWSClient)
+
+	regions, err := parseDefaultVpcDeletionImportID(d.Id(), client.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("dry_run", false)
+	d.Set("force", false)
+	d.Set("restore_on_destroy", false)
+
+	if regions == nil {
+		d.SetId(noDefaultVPC)
+		d.Set("region", client.Region)
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if err := d.Set("regions", regions); err != nil {
+		return nil, fmt.Errorf("error setting regions: %w", err)
+	}
+
+	expanded, err := expandDefaultVpcDeletionRegions(client.EC2Conn, append([]string{}, regions...))
+	if err != nil {
+		return nil, err
+	}
+
+	deletedVpcIDs := make(map[string]string, len(expanded))
+	for _, region := range expanded {
+		deletedVpcIDs[region] = noDefaultVPC
+	}
+
+	d.SetId(uuid.New().String())
+
+	if err := d.Set("deleted_vpc_ids", deletedVpcIDs); err != nil {
+		return nil, fmt.Errorf("error setting deleted_vpc_ids: %w", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseDefaultVpcDeletionImportID returns the `regions` to import, or nil for a resource without `regions`. An ID
+// that is a single region must be the provider's region, so that the imported state matches a configuration
+// without `regions`. A list of regions, including a single region followed by a comma, or "all" is imported as the
+// configured `regions`.
+func parseDefaultVpcDeletionImportID(id, providerRegion string) ([]string, error) {
+	id = strings.TrimSpace(id)
+
+	if id == allRegions {
+		return []string{allRegions}, nil
+	}
+
+	if !strings.Contains(id, ",") {
+		if id != providerRegion {
+			return nil, fmt.Errorf("unexpected ID (%s), expected the provider's region (%s) for a resource without regions, "+
+				"or a comma-separated list of the configured regions, such as %s,", id, providerRegion, id)
+		}
+
+		return nil, nil
+	}
+
+	var regions []string
+
+	for _, region := range strings.Split(id, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("unexpected format for ID (%s), expected REGION or REGION,REGION,... or %s", id, allRegions)
+	}
+
+	return regions, nil
+}
+
+func resourceDefaultVpcDeletionUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only restore_on_destroy can be updated, and it is only used on destroy.
+	return resourceDefaultVpcDeletionRead(d, meta)
+}
+
 func resourceDefaultVpcDeletionDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[INFO] Removing default VPC deletion state")
+	if !d.Get("restore_on_destroy").(bool) || d.Get("dry_run").(bool) {
+		log.Printf("[INFO] Removing default VPC deletion state")
+		return nil
+	}
+
+	var regions []string
+
+	if v, ok := d.GetOk("regions"); ok && v.(*schema.Set).Len() > 0 {
+		for region, vpcID := range d.Get("deleted_vpc_ids").(map[string]interface{}) {
+			if vpcID.(string) != noDefaultVPC {
+				regions = append(regions, region)
+			}
+		}
+	} else if d.Id() != noDefaultVPC {
+		region := d.Get("region").(string)
+		if region == "" {
+			region = meta.(*conns.AWSClient).Region
+		}
+
+		regions = []string{region}
+	}
+
+	err := forEachRegion(regions, func(region string) error {
+		log.Printf("[INFO] Restoring default VPC in region (%s)", region)
+		return restoreDefaultVpc(regionalEC2Conn(meta, region))
+	})
+
+	if err != nil {
+		return fmt.Errorf("error restoring default VPCs: %w", err)
+	}
+
 	return nil
 }
 
-func deleteInternetGateway(conn *ec2.EC2, vpcID string) error {
-	// Detach and Delete Internet Gateway
-	ig, err := FindInternetGatewayForVPC(conn, vpcID)
+// restoreDefaultVpc creates a new default VPC and waits for it to become available.
+// Nothing is done when a default VPC already exists.
+func restoreDefaultVpc(conn *ec2.EC2) error {
+	output, err := conn.CreateDefaultVpc(&ec2.CreateDefaultVpcInput{})
+
+	if tfawserr.ErrCodeEquals(err, errCodeDefaultVpcAlreadyExists) {
+		return nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("error while looking for EC2 Internet Gateway for VPC (%s): %w", vpcID, err)
+		return fmt.Errorf("error creating default VPC: %w", err)
 	}
 
-	if ig != nil {
-		igid := *ig.InternetGatewayId
-		detachInternetGatewayInput := &ec2.DetachInternetGatewayInput{
-			InternetGatewayId: aws.String(igid),
-			VpcId:             &vpcID,
+	vpcID := aws.StringValue(output.Vpc.VpcId)
+
+	err = tfresource.WaitUntil(defaultVpcRestoreTimeout, func() (bool, error) {
+		vpc, err := FindDefaultVpc(conn)
+		if err != nil {
+			return false, err
 		}
 
-		if _, err = conn.DetachInternetGateway(detachInternetGatewayInput); err != nil {
-			return fmt.Errorf("error while detaching EC2 Internet Gateway (%s): %w", igid, err)
+		return vpc != nil && aws.StringValue(vpc.VpcId) == vpcID && aws.StringValue(vpc.State) == ec2.VpcStateAvailable, nil
+	}, tfresource.WaitOpts{
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error waiting for default VPC (%s) to become available: %w", vpcID, err)
+	}
+
+	return nil
+}
+
+// defaultVpcDeletionResult describes the default VPC deletion, or the inspection in dry run mode, in a single region.
+type defaultVpcDeletionResult struct {
+	VpcID             string
+	CidrBlock         string
+	PlannedDeletions  []string
+	BlockingResources []string
+}
+
+// setDefaultVpcDeletionResults sets the computed attributes from per-region results. Entries are prefixed with their
+// region, unless the results are keyed by the empty string for the provider's configured region.
+func setDefaultVpcDeletionResults(d *schema.ResourceData, results map[string]*defaultVpcDeletionResult) error {
+	regions := make([]string, 0, len(results))
+	for region := range results {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	plannedDeletions := make([]string, 0)
+	blockingResources := make([]string, 0)
+
+	for _, region := range regions {
+		prefix := ""
+		if region != "" {
+			prefix = region + ": "
 		}
 
-		deleteInternetGatewayInput := &ec2.DeleteInternetGatewayInput{
-			InternetGatewayId: aws.String(igid),
+		for _, v := range results[region].PlannedDeletions {
+			plannedDeletions = append(plannedDeletions, prefix+v)
 		}
 
-		if _, err = conn.DeleteInternetGateway(deleteInternetGatewayInput); err != nil {
-			return fmt.Errorf("error while deleting EC2 Internet Gateway (%s): %w", igid, err)
+		for _, v := range results[region].BlockingResources {
+			blockingResources = append(blockingResources, prefix+v)
 		}
 	}
+
+	if err := d.Set("planned_deletions", plannedDeletions); err != nil {
+		return fmt.Errorf("error setting planned_deletions: %w", err)
+	}
+
+	if err := d.Set("blocking_resources", blockingResources); err != nil {
+		return fmt.Errorf("error setting blocking_resources: %w", err)
+	}
+
 	return nil
 }
 
-func deleteSubnets(conn *ec2.EC2, vpcID string) error {
-	subnets, err := FindSubnetsForVPC(conn, vpcID)
+// deleteDefaultVpc deletes the default VPC reachable through conn along with its child resources. The VPC is only
+// deleted when the pre-flight inspection finds nothing blocking, or when force is set, in which case its instances
+// are terminated and its network interfaces deleted as well. Nothing is deleted when dryRun is set. The result's VpcID is noDefaultVPC when there is no default VPC.
+func deleteDefaultVpc(conn *ec2.EC2, dryRun, force bool) (*defaultVpcDeletionResult, error) {
+	vpc, err := FindDefaultVpc(conn)
 	if err != nil {
-		return fmt.Errorf("error while looking for EC2 Subnets for VPC (%s): %w", vpcID, err)
+		return nil, err
 	}
 
-	for _, s := range subnets {
-		deleteSubnetInput := &ec2.DeleteSubnetInput{
-			SubnetId: s.SubnetId,
+	if vpc == nil {
+		return &defaultVpcDeletionResult{VpcID: noDefaultVPC}, nil
+	}
+
+	plan, err := planVpcTeardown(conn, vpc)
+	if err != nil {
+		return nil, err
+	}
+
+	blockers, err := plan.FindBlockers(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blockers) > 0 && force {
+		if err = plan.AddInUseObjects(conn); err != nil {
+			return nil, err
 		}
+	}
+
+	result := &defaultVpcDeletionResult{
+		VpcID:             plan.VpcID,
+		CidrBlock:         aws.StringValue(vpc.CidrBlock),
+		PlannedDeletions:  plan.Deletions(),
+		BlockingResources: blockers,
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if len(blockers) > 0 && !force {
+		return nil, fmt.Errorf("refusing to delete default VPC (%s) as it is in use by %s; set force = true to delete it anyway", plan.VpcID, strings.Join(blockers, ", "))
+	}
+
+	if err = plan.Execute(conn); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// deleteDefaultVpcsInRegions deletes the default VPC in each of the given regions. The returned map contains an
+// entry for every region that was processed successfully, even when an error is returned for other regions.
+func deleteDefaultVpcsInRegions(meta interface{}, regions []string, dryRun, force bool) (map[string]*defaultVpcDeletionResult, error) {
+	var mu sync.Mutex
+	results := make(map[string]*defaultVpcDeletionResult, len(regions))
 
-		if _, err = conn.DeleteSubnet(deleteSubnetInput); err != nil {
-			return fmt.Errorf("error while deleting EC2 Subnet (%s): %w", *s.SubnetId, err)
+	err := forEachRegion(regions, func(region string) error {
+		log.Printf("[INFO] Deleting default VPC in region (%s)", region)
+		result, err := deleteDefaultVpc(regionalEC2Conn(meta, region), dryRun, force)
+		if err != nil {
+			return err
 		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		results[region] = result
+
+		return nil
+	})
+
+	return results, err
+}
+
+// forEachRegion calls f for each of the given regions, processing at most defaultVpcDeletionMaxConcurrency
+// regions at the same time. Errors from all regions are collected and returned together.
+func forEachRegion(regions []string, f func(region string) error) error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs *multierror.Error
+		sem  = make(chan struct{}, defaultVpcDeletionMaxConcurrency)
+	)
+
+	for _, region := range regions {
+		wg.Add(1)
+
+		go func(region string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := f(region); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = multierror.Append(errs, fmt.Errorf("region (%s): %w", region, err))
+			}
+		}(region)
 	}
-	return nil
+
+	wg.Wait()
+
+	return errs.ErrorOrNil()
 }
 
-func deleteVpc(conn *ec2.EC2, vpcID string) error {
-	deleteVpcInput := &ec2.DeleteVpcInput{
-		VpcId: aws.String(vpcID),
+// expandDefaultVpcDeletionRegions resolves the configured `regions` to a sorted list of region names,
+// expanding `all` to every region enabled for the account.
+func expandDefaultVpcDeletionRegions(conn *ec2.EC2, configured []string) ([]string, error) {
+	for _, region := range configured {
+		if region == allRegions && len(configured) > 1 {
+			return nil, fmt.Errorf("%q cannot be combined with other regions", allRegions)
+		}
 	}
 
-	if _, err := conn.DeleteVpc(deleteVpcInput); err != nil {
-		return fmt.Errorf("error while deleting EC2 VPC (%s): %w", vpcID, err)
+	regions := configured
+
+	if len(configured) == 1 && configured[0] == allRegions {
+		enabled, err := FindEnabledRegionNames(conn)
+		if err != nil {
+			return nil, fmt.Errorf("error while listing enabled regions: %w", err)
+		}
+
+		regions = enabled
 	}
 
-	return nil
+	sort.Strings(regions)
+
+	return regions, nil
+}
+
+// regionalEC2Conn returns an EC2 connection for region, reusing the provider's connection when the
+// region matches the configured region.
+func regionalEC2Conn(meta interface{}, region string) *ec2.EC2 {
+	client := meta.(*conns.AWSClient)
+
+	if region == client.Region {
+		return client.EC2Conn
+	}
+
+	return ec2.New(client.Session.Copy(&aws.Config{Region: aws.String(region)}))
 }
@@ -0,0 +1,76 @@
+package ec2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDefaultVpcDeletionImportID(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		ID            string
+		Expected      []string
+		ExpectedError bool
+	}{
+		{
+			Name: "provider region",
+			ID:   "us-east-1",
+		},
+		{
+			Name:          "other region",
+			ID:            "us-west-2",
+			ExpectedError: true,
+		},
+		{
+			Name:     "single region list",
+			ID:       "us-west-2,",
+			Expected: []string{"us-west-2"},
+		},
+		{
+			Name:     "provider region list",
+			ID:       "us-east-1,",
+			Expected: []string{"us-east-1"},
+		},
+		{
+			Name:     "region list",
+			ID:       "us-east-1, us-west-2",
+			Expected: []string{"us-east-1", "us-west-2"},
+		},
+		{
+			Name:     "all",
+			ID:       "all",
+			Expected: []string{"all"},
+		},
+		{
+			Name:          "empty list",
+			ID:            ",",
+			ExpectedError: true,
+		},
+		{
+			Name:          "empty",
+			ID:            "",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			got, err := parseDefaultVpcDeletionImportID(testCase.ID, "us-east-1")
+
+			if testCase.ExpectedError {
+				if err == nil {
+					t.Fatalf("expected error, got regions %v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("expected %v, got %v", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,842 @@
+package ec2
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+)
+
+const (
+	// vpcTeardownDependencyTimeout is how long a deletion is retried while AWS reports a DependencyViolation,
+	// which is common while the ENIs of recently deleted objects are released.
+	vpcTeardownDependencyTimeout = 5 * time.Minute
+
+	// vpcTeardownWaitTimeout is how long to wait for asynchronously deleted objects to disappear.
+	vpcTeardownWaitTimeout = 10 * time.Minute
+)
+
+// Object types reported by the VPC teardown planner.
+const (
+	vpcTeardownObjectDHCPOptions               = "DHCP Options Set"
+	vpcTeardownObjectEgressOnlyInternetGateway = "Egress-only Internet Gateway"
+	vpcTeardownObjectInstance                  = "Instance"
+	vpcTeardownObjectInternetGateway           = "Internet Gateway"
+	vpcTeardownObjectNATGateway                = "NAT Gateway"
+	vpcTeardownObjectNetworkACL                = "Network ACL"
+	vpcTeardownObjectNetworkInterface          = "Network Interface"
+	vpcTeardownObjectRouteTable                = "Route Table"
+	vpcTeardownObjectSecurityGroup             = "Security Group"
+	vpcTeardownObjectSecurityGroupRules        = "Security Group Rules"
+	vpcTeardownObjectSubnet                    = "Subnet"
+	vpcTeardownObjectVPC                       = "VPC"
+	vpcTeardownObjectVPCEndpoint               = "VPC Endpoint"
+	vpcTeardownObjectVPCPeeringConnection      = "VPC Peering Connection"
+	vpcTeardownObjectVPNGatewayAttachment      = "VPN Gateway Attachment"
+)
+
+// vpcTeardownStep is a single deletion in a vpcTeardownPlan.
+type vpcTeardownStep struct {
+	ObjectType string
+	ID         string
+	// Default is set for objects that AWS creates as part of a default VPC.
+	Default bool
+
+	run func(conn *ec2.EC2) error
+}
+
+func (s *vpcTeardownStep) String() string {
+	return fmt.Sprintf("%s (%s)", s.ObjectType, s.ID)
+}
+
+// vpcTeardownPlan is the dependency-ordered list of deletions required to delete a VPC and every object that lives in it.
+type vpcTeardownPlan struct {
+	VpcID string
+	Steps []*vpcTeardownStep
+}
+
+// planVpcTeardown discovers every child object of the VPC and orders their deletion so that no object is deleted
+// before the objects that depend on it. Instances and attached network interfaces are only part of the plan once
+// AddInUseObjects is called:
+//
+//  1. VPC endpoints and NAT gateways, which own requester-managed network interfaces
+//  2. VPC peering connections and VPN gateway attachments
+//  3. Egress-only and regular internet gateways
+//  4. Detached network interfaces
+//  5. Security group rules referencing other groups, then non-default security groups
+//  6. Non-main route tables, subnets and non-default network ACLs
+//  7. The VPC itself, followed by its DHCP options set when no other VPC uses it and it was not created by AWS
+func planVpcTeardown(conn *ec2.EC2, vpc *ec2.Vpc) (*vpcTeardownPlan, error) {
+	vpcID := aws.StringValue(vpc.VpcId)
+	plan := &vpcTeardownPlan{VpcID: vpcID}
+	vpcFilter := BuildAttributeFilterList(map[string]string{"vpc-id": vpcID})
+
+	endpoints, err := FindVPCEndpoints(conn, &ec2.DescribeVpcEndpointsInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 VPC Endpoints for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range endpoints {
+		if state := strings.ToLower(aws.StringValue(v.State)); state == vpcEndpointStateDeleted || state == vpcEndpointStateDeleting {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectVPCEndpoint, aws.StringValue(v.VpcEndpointId), deleteVpcEndpoint)
+	}
+
+	natGateways, err := FindNATGateways(conn, &ec2.DescribeNatGatewaysInput{Filter: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 NAT Gateways for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range natGateways {
+		if state := aws.StringValue(v.State); state == ec2.NatGatewayStateDeleted || state == ec2.NatGatewayStateDeleting {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectNATGateway, aws.StringValue(v.NatGatewayId), deleteNATGateway)
+	}
+
+	for _, filterName := range []string{"requester-vpc-info.vpc-id", "accepter-vpc-info.vpc-id"} {
+		peeringConnections, err := FindVPCPeeringConnections(conn, &ec2.DescribeVpcPeeringConnectionsInput{
+			Filters: BuildAttributeFilterList(map[string]string{filterName: vpcID}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while looking for EC2 VPC Peering Connections for VPC (%s): %w", vpcID, err)
+		}
+
+		for _, v := range peeringConnections {
+			if v.Status != nil {
+				switch aws.StringValue(v.Status.Code) {
+				case ec2.VpcPeeringConnectionStateReasonCodeDeleted, ec2.VpcPeeringConnectionStateReasonCodeDeleting,
+					ec2.VpcPeeringConnectionStateReasonCodeExpired, ec2.VpcPeeringConnectionStateReasonCodeFailed,
+					ec2.VpcPeeringConnectionStateReasonCodeRejected:
+					continue
+				}
+			}
+
+			plan.add(vpcTeardownObjectVPCPeeringConnection, aws.StringValue(v.VpcPeeringConnectionId), deleteVpcPeeringConnection)
+		}
+	}
+
+	vpnGateways, err := conn.DescribeVpnGateways(&ec2.DescribeVpnGatewaysInput{
+		Filters: BuildAttributeFilterList(map[string]string{"attachment.vpc-id": vpcID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 VPN Gateways for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range vpnGateways.VpnGateways {
+		if v == nil {
+			continue
+		}
+
+		for _, attachment := range v.VpcAttachments {
+			if aws.StringValue(attachment.VpcId) != vpcID || aws.StringValue(attachment.State) == ec2.AttachmentStatusDetached {
+				continue
+			}
+
+			plan.add(vpcTeardownObjectVPNGatewayAttachment, aws.StringValue(v.VpnGatewayId), func(conn *ec2.EC2, id string) error {
+				return detachVpnGateway(conn, id, vpcID)
+			})
+		}
+	}
+
+	egressOnlyInternetGateways, err := FindEgressOnlyInternetGateways(conn, &ec2.DescribeEgressOnlyInternetGatewaysInput{})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Egress-only Internet Gateways for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range egressOnlyInternetGateways {
+		for _, attachment := range v.Attachments {
+			if aws.StringValue(attachment.VpcId) == vpcID {
+				plan.add(vpcTeardownObjectEgressOnlyInternetGateway, aws.StringValue(v.EgressOnlyInternetGatewayId), deleteEgressOnlyInternetGateway)
+				break
+			}
+		}
+	}
+
+	internetGateways, err := FindInternetGateways(conn, &ec2.DescribeInternetGatewaysInput{
+		Filters: BuildAttributeFilterList(map[string]string{"attachment.vpc-id": vpcID}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Internet Gateway for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range internetGateways {
+		plan.add(vpcTeardownObjectInternetGateway, aws.StringValue(v.InternetGatewayId), func(conn *ec2.EC2, id string) error {
+			return deleteInternetGateway(conn, id, vpcID)
+		}).Default = true
+	}
+
+	networkInterfaces, err := FindNetworkInterfaces(conn, &ec2.DescribeNetworkInterfacesInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Network Interfaces for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range networkInterfaces {
+		// Attached and requester-managed interfaces are released by deleting their owner; anything left
+		// over is reported as the blocking object when the subnet or VPC deletion fails.
+		if aws.StringValue(v.Status) != ec2.NetworkInterfaceStatusAvailable || aws.BoolValue(v.RequesterManaged) {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectNetworkInterface, aws.StringValue(v.NetworkInterfaceId), deleteNetworkInterface)
+	}
+
+	securityGroups, err := FindSecurityGroups(conn, &ec2.DescribeSecurityGroupsInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Security Groups for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range securityGroups {
+		if ingress, egress := securityGroupReferencingPermissions(v); len(ingress) > 0 || len(egress) > 0 {
+			plan.add(vpcTeardownObjectSecurityGroupRules, aws.StringValue(v.GroupId), func(conn *ec2.EC2, id string) error {
+				return revokeSecurityGroupPermissions(conn, id, ingress, egress)
+			})
+		}
+	}
+
+	for _, v := range securityGroups {
+		if aws.StringValue(v.GroupName) == DefaultSecurityGroupName {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectSecurityGroup, aws.StringValue(v.GroupId), deleteSecurityGroup)
+	}
+
+	routeTables, err := FindRouteTables(conn, &ec2.DescribeRouteTablesInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Route Tables for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range routeTables {
+		var associationIDs []string
+		main := false
+
+		for _, association := range v.Associations {
+			if aws.BoolValue(association.Main) {
+				main = true
+				break
+			}
+
+			associationIDs = append(associationIDs, aws.StringValue(association.RouteTableAssociationId))
+		}
+
+		// The main route table is deleted along with the VPC.
+		if main {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectRouteTable, aws.StringValue(v.RouteTableId), func(conn *ec2.EC2, id string) error {
+			return deleteRouteTable(conn, id, associationIDs)
+		})
+	}
+
+	subnets, err := FindSubnetsForVPC(conn, vpcID)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Subnets for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range subnets {
+		plan.add(vpcTeardownObjectSubnet, aws.StringValue(v.SubnetId), deleteSubnet).Default = aws.BoolValue(v.DefaultForAz)
+	}
+
+	networkACLs, err := FindNetworkACLs(conn, &ec2.DescribeNetworkAclsInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, fmt.Errorf("error while looking for EC2 Network ACLs for VPC (%s): %w", vpcID, err)
+	}
+
+	for _, v := range networkACLs {
+		// The default network ACL is deleted along with the VPC.
+		if aws.BoolValue(v.IsDefault) {
+			continue
+		}
+
+		plan.add(vpcTeardownObjectNetworkACL, aws.StringValue(v.NetworkAclId), deleteNetworkACL)
+	}
+
+	plan.add(vpcTeardownObjectVPC, vpcID, deleteVpc).Default = true
+
+	if dhcpOptionsID := aws.StringValue(vpc.DhcpOptionsId); dhcpOptionsID != "" && dhcpOptionsID != DefaultDHCPOptionsID {
+		dhcpOptions, err := FindDHCPOptionsByID(conn, dhcpOptionsID)
+
+		switch {
+		case tfresource.NotFound(err):
+		case err != nil:
+			return nil, fmt.Errorf("error while looking for EC2 DHCP Options Set (%s) for VPC (%s): %w", dhcpOptionsID, vpcID, err)
+		default:
+			plan.addDHCPOptions(dhcpOptionsID, dhcpOptions)
+		}
+	}
+
+	return plan, nil
+}
+
+// addDHCPOptions plans the deletion of the DHCP options set associated with the VPC. A custom set is not part of a
+// default VPC, so it is reported as blocking the deletion unless force is set.
+func (p *vpcTeardownPlan) addDHCPOptions(id string, dhcpOptions *ec2.DhcpOptions) {
+	if isAWSCreatedDHCPOptions(dhcpOptions) {
+		// The options set AWS creates with the region's first default VPC is shared with the VPCs created later,
+		// which would lose AmazonProvidedDNS if it were deleted.
+		log.Printf("[INFO] Keeping AWS-created EC2 %s (%s)", vpcTeardownObjectDHCPOptions, id)
+		return
+	}
+
+	p.add(vpcTeardownObjectDHCPOptions, id, deleteUnusedDHCPOptions)
+}
+
+func (p *vpcTeardownPlan) add(objectType, id string, run func(conn *ec2.EC2, id string) error) *vpcTeardownStep {
+	step := &vpcTeardownStep{
+		ObjectType: objectType,
+		ID:         id,
+		run: func(conn *ec2.EC2) error {
+			return run(conn, id)
+		},
+	}
+
+	p.Steps = append(p.Steps, step)
+
+	return step
+}
+
+// Deletions returns a description of each object the plan deletes, in deletion order.
+func (p *vpcTeardownPlan) Deletions() []string {
+	deletions := make([]string, 0, len(p.Steps))
+
+	for _, step := range p.Steps {
+		deletions = append(deletions, step.String())
+	}
+
+	return deletions
+}
+
+// FindBlockers inspects the VPC for anything that suggests it is in use and should not be deleted: instances that
+// have not been terminated, network interfaces not managed by AWS and any object that is not part of a default VPC.
+func (p *vpcTeardownPlan) FindBlockers(conn *ec2.EC2) ([]string, error) {
+	var blockers []string
+
+	instances, networkInterfaces, err := p.findInUseObjects(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceIDs := make(map[string]bool, len(instances))
+	for _, v := range instances {
+		instanceIDs[aws.StringValue(v.InstanceId)] = true
+		blockers = append(blockers, fmt.Sprintf("%s (%s) %s", vpcTeardownObjectInstance, aws.StringValue(v.InstanceId), aws.StringValue(v.State.Name)))
+	}
+
+	for _, v := range networkInterfaces {
+		// Already reported through its instance.
+		if v.Attachment != nil && instanceIDs[aws.StringValue(v.Attachment.InstanceId)] {
+			continue
+		}
+
+		blockers = append(blockers, fmt.Sprintf("%s (%s)", vpcTeardownObjectNetworkInterface, aws.StringValue(v.NetworkInterfaceId)))
+	}
+
+	return append(blockers, p.stepBlockers()...), nil
+}
+
+// stepBlockers returns a description of each planned object that is not part of a default VPC.
+func (p *vpcTeardownPlan) stepBlockers() []string {
+	var blockers []string
+
+	for _, step := range p.Steps {
+		if step.Default || step.ObjectType == vpcTeardownObjectNetworkInterface {
+			continue
+		}
+
+		blockers = append(blockers, step.String())
+	}
+
+	return blockers
+}
+
+// AddInUseObjects puts the termination of the VPC's instances and the deletion of its network interfaces not managed
+// by AWS at the start of the plan, so that a VPC that is in use can be deleted. Network interfaces that are still
+// attached once their instance is terminated are detached first.
+func (p *vpcTeardownPlan) AddInUseObjects(conn *ec2.EC2) error {
+	instances, networkInterfaces, err := p.findInUseObjects(conn)
+	if err != nil {
+		return err
+	}
+
+	steps := p.Steps
+	p.Steps = nil
+
+	for _, v := range instances {
+		p.add(vpcTeardownObjectInstance, aws.StringValue(v.InstanceId), terminateInstance)
+	}
+
+	for _, v := range networkInterfaces {
+		// Detached interfaces are already part of the plan.
+		if aws.StringValue(v.Status) == ec2.NetworkInterfaceStatusAvailable {
+			continue
+		}
+
+		p.add(vpcTeardownObjectNetworkInterface, aws.StringValue(v.NetworkInterfaceId), detachAndDeleteNetworkInterface)
+	}
+
+	p.Steps = append(p.Steps, steps...)
+
+	return nil
+}
+
+// findInUseObjects returns the instances of the VPC that have not been terminated and its network interfaces not
+// managed by AWS.
+func (p *vpcTeardownPlan) findInUseObjects(conn *ec2.EC2) ([]*ec2.Instance, []*ec2.NetworkInterface, error) {
+	vpcFilter := BuildAttributeFilterList(map[string]string{"vpc-id": p.VpcID})
+
+	instances, err := FindInstances(conn, &ec2.DescribeInstancesInput{
+		Filters: append(vpcFilter, NewFilter("instance-state-name", []string{
+			ec2.InstanceStateNamePending,
+			ec2.InstanceStateNameRunning,
+			ec2.InstanceStateNameShuttingDown,
+			ec2.InstanceStateNameStopping,
+			ec2.InstanceStateNameStopped,
+		})),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while looking for EC2 Instances for VPC (%s): %w", p.VpcID, err)
+	}
+
+	networkInterfaces, err := FindNetworkInterfaces(conn, &ec2.DescribeNetworkInterfacesInput{Filters: vpcFilter})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while looking for EC2 Network Interfaces for VPC (%s): %w", p.VpcID, err)
+	}
+
+	var unmanaged []*ec2.NetworkInterface
+	for _, v := range networkInterfaces {
+		if !aws.BoolValue(v.RequesterManaged) {
+			unmanaged = append(unmanaged, v)
+		}
+	}
+
+	return instances, unmanaged, nil
+}
+
+// Execute runs each step of the plan in order, stopping at the first failure. The returned error names the
+// object whose deletion failed and, on a DependencyViolation, the network interfaces still present in the VPC.
+func (p *vpcTeardownPlan) Execute(conn *ec2.EC2) error {
+	for _, step := range p.Steps {
+		log.Printf("[INFO] Deleting EC2 %s for VPC (%s)", step, p.VpcID)
+
+		if err := step.run(conn); err != nil {
+			if tfawserr.ErrCodeEquals(err, errCodeDependencyViolation) {
+				if blockers := describeVpcTeardownBlockers(conn, p.VpcID); blockers != "" {
+					return fmt.Errorf("error while deleting EC2 %s in VPC (%s), blocked by %s: %w", step, p.VpcID, blockers, err)
+				}
+			}
+
+			return fmt.Errorf("error while deleting EC2 %s in VPC (%s): %w", step, p.VpcID, err)
+		}
+	}
+
+	return nil
+}
+
+// describeVpcTeardownBlockers lists the network interfaces remaining in the VPC, which are what AWS most often
+// reports as a DependencyViolation.
+func describeVpcTeardownBlockers(conn *ec2.EC2, vpcID string) string {
+	networkInterfaces, err := FindNetworkInterfaces(conn, &ec2.DescribeNetworkInterfacesInput{
+		Filters: BuildAttributeFilterList(map[string]string{"vpc-id": vpcID}),
+	})
+	if err != nil || len(networkInterfaces) == 0 {
+		return ""
+	}
+
+	blockers := make([]string, 0, len(networkInterfaces))
+	for _, v := range networkInterfaces {
+		blocker := fmt.Sprintf("%s %s (%s)", vpcTeardownObjectNetworkInterface, aws.StringValue(v.NetworkInterfaceId), aws.StringValue(v.Status))
+		if description := aws.StringValue(v.Description); description != "" {
+			blocker += fmt.Sprintf(" %q", description)
+		}
+		blockers = append(blockers, blocker)
+	}
+
+	return strings.Join(blockers, ", ")
+}
+
+// retryOnDependencyViolation retries f while it fails with a DependencyViolation and ignores the given not found error codes.
+func retryOnDependencyViolation(f func() error, notFoundCodes ...string) error {
+	_, err := tfresource.RetryWhenAWSErrCodeEquals(vpcTeardownDependencyTimeout, func() (interface{}, error) {
+		return nil, f()
+	}, errCodeDependencyViolation)
+
+	if len(notFoundCodes) > 0 && tfawserr.ErrCodeEquals(err, notFoundCodes...) {
+		return nil
+	}
+
+	return err
+}
+
+func deleteVpcEndpoint(conn *ec2.EC2, id string) error {
+	output, err := conn.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: aws.StringSlice([]string{id}),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeInvalidVPCEndpointIdNotFound, errCodeInvalidVPCEndpointNotFound) {
+		return nil
+	}
+
+	if err == nil && output != nil {
+		err = UnsuccessfulItemsError(output.Unsuccessful)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tfresource.RetryUntilNotFound(vpcTeardownWaitTimeout, func() (interface{}, error) {
+		return FindVPCEndpointByID(conn, id)
+	})
+
+	return err
+}
+
+func deleteNATGateway(conn *ec2.EC2, id string) error {
+	_, err := conn.DeleteNatGateway(&ec2.DeleteNatGatewayInput{
+		NatGatewayId: aws.String(id),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeNatGatewayNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tfresource.RetryUntilNotFound(vpcTeardownWaitTimeout, func() (interface{}, error) {
+		return FindNATGatewayByID(conn, id)
+	})
+
+	return err
+}
+
+func deleteVpcPeeringConnection(conn *ec2.EC2, id string) error {
+	_, err := conn.DeleteVpcPeeringConnection(&ec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: aws.String(id),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeInvalidVPCPeeringConnectionIDNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func detachVpnGateway(conn *ec2.EC2, vpnGatewayID, vpcID string) error {
+	_, err := conn.DetachVpnGateway(&ec2.DetachVpnGatewayInput{
+		VpcId:        aws.String(vpcID),
+		VpnGatewayId: aws.String(vpnGatewayID),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeInvalidVPNGatewayAttachmentNotFound, errCodeInvalidVPNGatewayIDNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tfresource.RetryUntilNotFound(vpcTeardownWaitTimeout, func() (interface{}, error) {
+		return FindVPNGatewayVPCAttachment(conn, vpnGatewayID, vpcID)
+	})
+
+	return err
+}
+
+func deleteEgressOnlyInternetGateway(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteEgressOnlyInternetGateway(&ec2.DeleteEgressOnlyInternetGatewayInput{
+			EgressOnlyInternetGatewayId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidGatewayIDNotFound)
+}
+
+func deleteInternetGateway(conn *ec2.EC2, internetGatewayID, vpcID string) error {
+	// Detaching fails with a DependencyViolation while public addresses are still mapped in the VPC.
+	err := retryOnDependencyViolation(func() error {
+		_, err := conn.DetachInternetGateway(&ec2.DetachInternetGatewayInput{
+			InternetGatewayId: aws.String(internetGatewayID),
+			VpcId:             aws.String(vpcID),
+		})
+		return err
+	}, errCodeGatewayNotAttached, errCodeInvalidInternetGatewayIDNotFound)
+
+	if err != nil {
+		return fmt.Errorf("detaching: %w", err)
+	}
+
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteInternetGateway(&ec2.DeleteInternetGatewayInput{
+			InternetGatewayId: aws.String(internetGatewayID),
+		})
+		return err
+	}, errCodeInvalidInternetGatewayIDNotFound)
+}
+
+func terminateInstance(conn *ec2.EC2, id string) error {
+	_, err := conn.TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIds: aws.StringSlice([]string{id}),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeInvalidInstanceIDNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Terminated instances are reported as not found.
+	_, err = tfresource.RetryUntilNotFound(vpcTeardownWaitTimeout, func() (interface{}, error) {
+		return FindInstanceByID(conn, id)
+	})
+
+	return err
+}
+
+// detachAndDeleteNetworkInterface deletes a network interface that may still be attached. Interfaces attached to an
+// instance being terminated are released, or deleted, by the termination and are only waited for.
+func detachAndDeleteNetworkInterface(conn *ec2.EC2, id string) error {
+	networkInterface, err := FindNetworkInterfaceByID(conn, id)
+
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if attachment := networkInterface.Attachment; attachment != nil && aws.StringValue(networkInterface.Status) == ec2.NetworkInterfaceStatusInUse {
+		instance, err := FindInstanceByID(conn, aws.StringValue(attachment.InstanceId))
+
+		switch {
+		case tfresource.NotFound(err):
+		case err != nil:
+			return err
+		case aws.StringValue(instance.State.Name) != ec2.InstanceStateNameShuttingDown:
+			_, err = conn.DetachNetworkInterface(&ec2.DetachNetworkInterfaceInput{
+				AttachmentId: attachment.AttachmentId,
+				Force:        aws.Bool(true),
+			})
+
+			if err != nil && !tfawserr.ErrCodeEquals(err, errCodeInvalidAttachmentIDNotFound) {
+				return fmt.Errorf("detaching: %w", err)
+			}
+		}
+	}
+
+	err = tfresource.WaitUntil(vpcTeardownWaitTimeout, func() (bool, error) {
+		networkInterface, err := FindNetworkInterfaceByID(conn, id)
+
+		if tfresource.NotFound(err) {
+			return true, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		return aws.StringValue(networkInterface.Status) == ec2.NetworkInterfaceStatusAvailable, nil
+	}, tfresource.WaitOpts{})
+
+	if err != nil {
+		return fmt.Errorf("waiting for detachment: %w", err)
+	}
+
+	return deleteNetworkInterface(conn, id)
+}
+
+func deleteNetworkInterface(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteNetworkInterface(&ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidNetworkInterfaceIDNotFound)
+}
+
+// securityGroupReferencingPermissions returns the ingress and egress permissions of the security group that
+// reference other security groups, which must be revoked before the referenced groups can be deleted.
+// References to the group itself never block a deletion and are left alone.
+func securityGroupReferencingPermissions(sg *ec2.SecurityGroup) ([]*ec2.IpPermission, []*ec2.IpPermission) {
+	return referencingPermissions(sg.IpPermissions, aws.StringValue(sg.GroupId)), referencingPermissions(sg.IpPermissionsEgress, aws.StringValue(sg.GroupId))
+}
+
+func referencingPermissions(permissions []*ec2.IpPermission, groupID string) []*ec2.IpPermission {
+	var referencing []*ec2.IpPermission
+
+	for _, v := range permissions {
+		var pairs []*ec2.UserIdGroupPair
+
+		for _, pair := range v.UserIdGroupPairs {
+			if aws.StringValue(pair.GroupId) != groupID {
+				pairs = append(pairs, pair)
+			}
+		}
+
+		if len(pairs) > 0 {
+			referencing = append(referencing, &ec2.IpPermission{
+				FromPort:         v.FromPort,
+				IpProtocol:       v.IpProtocol,
+				ToPort:           v.ToPort,
+				UserIdGroupPairs: pairs,
+			})
+		}
+	}
+
+	return referencing
+}
+
+func revokeSecurityGroupPermissions(conn *ec2.EC2, id string, ingress, egress []*ec2.IpPermission) error {
+	if len(ingress) > 0 {
+		_, err := conn.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: ingress,
+		})
+
+		if err != nil && !tfawserr.ErrCodeEquals(err, errCodeInvalidPermissionNotFound) {
+			return fmt.Errorf("revoking ingress rules: %w", err)
+		}
+	}
+
+	if len(egress) > 0 {
+		_, err := conn.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       aws.String(id),
+			IpPermissions: egress,
+		})
+
+		if err != nil && !tfawserr.ErrCodeEquals(err, errCodeInvalidPermissionNotFound) {
+			return fmt.Errorf("revoking egress rules: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func deleteSecurityGroup(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{
+			GroupId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidGroupNotFound, errCodeInvalidSecurityGroupIDNotFound)
+}
+
+func deleteRouteTable(conn *ec2.EC2, id string, associationIDs []string) error {
+	for _, associationID := range associationIDs {
+		_, err := conn.DisassociateRouteTable(&ec2.DisassociateRouteTableInput{
+			AssociationId: aws.String(associationID),
+		})
+
+		if err != nil && !tfawserr.ErrCodeEquals(err, errCodeInvalidAssociationIDNotFound) {
+			return fmt.Errorf("disassociating (%s): %w", associationID, err)
+		}
+	}
+
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteRouteTable(&ec2.DeleteRouteTableInput{
+			RouteTableId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidRouteTableIDNotFound)
+}
+
+func deleteSubnet(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteSubnet(&ec2.DeleteSubnetInput{
+			SubnetId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidSubnetIDNotFound)
+}
+
+func deleteNetworkACL(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteNetworkAcl(&ec2.DeleteNetworkAclInput{
+			NetworkAclId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidNetworkACLIDNotFound)
+}
+
+func deleteVpc(conn *ec2.EC2, id string) error {
+	return retryOnDependencyViolation(func() error {
+		_, err := conn.DeleteVpc(&ec2.DeleteVpcInput{
+			VpcId: aws.String(id),
+		})
+		return err
+	}, errCodeInvalidVPCIDNotFound)
+}
+
+// isAWSCreatedDHCPOptions reports whether the DHCP options set looks like the one AWS creates for the default VPCs of
+// a region: the region's internal domain name and AmazonProvidedDNS, and nothing else.
+func isAWSCreatedDHCPOptions(dhcpOptions *ec2.DhcpOptions) bool {
+	if len(dhcpOptions.DhcpConfigurations) != 2 {
+		return false
+	}
+
+	for _, configuration := range dhcpOptions.DhcpConfigurations {
+		var values []string
+		for _, value := range configuration.Values {
+			values = append(values, aws.StringValue(value.Value))
+		}
+
+		if len(values) != 1 {
+			return false
+		}
+
+		switch aws.StringValue(configuration.Key) {
+		case "domain-name":
+			if values[0] != "ec2.internal" && !strings.HasSuffix(values[0], ".compute.internal") {
+				return false
+			}
+		case "domain-name-servers":
+			if values[0] != "AmazonProvidedDNS" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// deleteUnusedDHCPOptions deletes the DHCP options set unless another VPC is still associated with it.
+func deleteUnusedDHCPOptions(conn *ec2.EC2, id string) error {
+	vpcs, err := FindVPCs(conn, &ec2.DescribeVpcsInput{
+		Filters: BuildAttributeFilterList(map[string]string{"dhcp-options-id": id}),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if len(vpcs) > 0 {
+		log.Printf("[INFO] EC2 %s (%s) is still associated with %d VPC(s), not deleting", vpcTeardownObjectDHCPOptions, id, len(vpcs))
+		return nil
+	}
+
+	_, err = conn.DeleteDhcpOptions(&ec2.DeleteDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+	})
+
+	if tfawserr.ErrCodeEquals(err, errCodeInvalidDHCPOptionIDNotFound) {
+		return nil
+	}
+
+	return err
+}
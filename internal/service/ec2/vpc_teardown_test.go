@@ -0,0 +1,183 @@
+package ec2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestSecurityGroupReferencingPermissions(t *testing.T) {
+	sg := &ec2.SecurityGroup{
+		GroupId: aws.String("sg-self"),
+		IpPermissions: []*ec2.IpPermission{
+			{
+				IpProtocol: aws.String("-1"),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{
+					{GroupId: aws.String("sg-self")},
+				},
+			},
+			{
+				FromPort:   aws.Int64(443),
+				IpProtocol: aws.String("tcp"),
+				ToPort:     aws.Int64(443),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{
+					{GroupId: aws.String("sg-self")},
+					{GroupId: aws.String("sg-other")},
+				},
+			},
+			{
+				FromPort:   aws.Int64(22),
+				IpProtocol: aws.String("tcp"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+				ToPort:     aws.Int64(22),
+			},
+		},
+		IpPermissionsEgress: []*ec2.IpPermission{
+			{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			},
+		},
+	}
+
+	ingress, egress := securityGroupReferencingPermissions(sg)
+
+	expectedIngress := []*ec2.IpPermission{
+		{
+			FromPort:   aws.Int64(443),
+			IpProtocol: aws.String("tcp"),
+			ToPort:     aws.Int64(443),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{
+				{GroupId: aws.String("sg-other")},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(ingress, expectedIngress) {
+		t.Errorf("expected ingress %s, got %s", expectedIngress, ingress)
+	}
+
+	if len(egress) != 0 {
+		t.Errorf("expected no egress, got %s", egress)
+	}
+}
+
+func TestVpcTeardownPlanDeletions(t *testing.T) {
+	noop := func(conn *ec2.EC2, id string) error { return nil }
+
+	plan := &vpcTeardownPlan{VpcID: "vpc-12345678"}
+	plan.add(vpcTeardownObjectVPCEndpoint, "vpce-12345678", noop)
+	plan.add(vpcTeardownObjectInternetGateway, "igw-12345678", noop).Default = true
+	plan.add(vpcTeardownObjectVPC, "vpc-12345678", noop).Default = true
+
+	expected := []string{
+		"VPC Endpoint (vpce-12345678)",
+		"Internet Gateway (igw-12345678)",
+		"VPC (vpc-12345678)",
+	}
+
+	if got := plan.Deletions(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestVpcTeardownPlanDHCPOptionsBlockers(t *testing.T) {
+	noop := func(conn *ec2.EC2, id string) error { return nil }
+
+	plan := &vpcTeardownPlan{VpcID: "vpc-12345678"}
+	plan.add(vpcTeardownObjectVPC, "vpc-12345678", noop).Default = true
+	plan.addDHCPOptions("dopt-aws", dhcpOptions(map[string][]string{
+		"domain-name":         {"ec2.internal"},
+		"domain-name-servers": {"AmazonProvidedDNS"},
+	}))
+	plan.addDHCPOptions("dopt-custom", dhcpOptions(map[string][]string{
+		"domain-name":         {"example.com"},
+		"domain-name-servers": {"AmazonProvidedDNS"},
+	}))
+
+	expectedDeletions := []string{
+		"VPC (vpc-12345678)",
+		"DHCP Options Set (dopt-custom)",
+	}
+
+	if got := plan.Deletions(); !reflect.DeepEqual(got, expectedDeletions) {
+		t.Errorf("expected deletions %v, got %v", expectedDeletions, got)
+	}
+
+	expectedBlockers := []string{
+		"DHCP Options Set (dopt-custom)",
+	}
+
+	if got := plan.stepBlockers(); !reflect.DeepEqual(got, expectedBlockers) {
+		t.Errorf("expected blockers %v, got %v", expectedBlockers, got)
+	}
+}
+
+func TestIsAWSCreatedDHCPOptions(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		DhcpOptions *ec2.DhcpOptions
+		Expected    bool
+	}{
+		{
+			Name: "us-east-1",
+			DhcpOptions: dhcpOptions(map[string][]string{
+				"domain-name":         {"ec2.internal"},
+				"domain-name-servers": {"AmazonProvidedDNS"},
+			}),
+			Expected: true,
+		},
+		{
+			Name: "other region",
+			DhcpOptions: dhcpOptions(map[string][]string{
+				"domain-name":         {"eu-west-1.compute.internal"},
+				"domain-name-servers": {"AmazonProvidedDNS"},
+			}),
+			Expected: true,
+		},
+		{
+			Name: "custom domain name",
+			DhcpOptions: dhcpOptions(map[string][]string{
+				"domain-name":         {"example.com"},
+				"domain-name-servers": {"AmazonProvidedDNS"},
+			}),
+		},
+		{
+			Name: "custom name servers",
+			DhcpOptions: dhcpOptions(map[string][]string{
+				"domain-name":         {"ec2.internal"},
+				"domain-name-servers": {"10.0.0.2", "AmazonProvidedDNS"},
+			}),
+		},
+		{
+			Name: "extra configuration",
+			DhcpOptions: dhcpOptions(map[string][]string{
+				"domain-name":         {"ec2.internal"},
+				"domain-name-servers": {"AmazonProvidedDNS"},
+				"ntp-servers":         {"169.254.169.123"},
+			}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := isAWSCreatedDHCPOptions(testCase.DhcpOptions); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func dhcpOptions(configurations map[string][]string) *ec2.DhcpOptions {
+	v := &ec2.DhcpOptions{}
+	for key, values := range configurations {
+		configuration := &ec2.DhcpConfiguration{Key: aws.String(key)}
+		for _, value := range values {
+			configuration.Values = append(configuration.Values, &ec2.AttributeValue{Value: aws.String(value)})
+		}
+		v.DhcpConfigurations = append(v.DhcpConfigurations, configuration)
+	}
+	return v
+}
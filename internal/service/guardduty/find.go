@@ -40,3 +40,29 @@ func IsGuardDutyOrganizationSettingsAutoEnabled(conn *guardduty.GuardDuty, detec
 
 	return *settings.AutoEnable, err
 }
+
+// FindMembers returns the member accounts of the administrator account, including the accounts that are no longer
+// associated with it.
+func FindMembers(conn *guardduty.GuardDuty, detectorID string) ([]*guardduty.Member, error) {
+	input := &guardduty.ListMembersInput{
+		DetectorId:     aws.String(detectorID),
+		OnlyAssociated: aws.String("false"),
+	}
+	var result []*guardduty.Member
+
+	err := conn.ListMembersPages(input, func(page *guardduty.ListMembersOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, member := range page.Members {
+			if member != nil {
+				result = append(result, member)
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
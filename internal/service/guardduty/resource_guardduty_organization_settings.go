@@ -2,28 +2,42 @@ package guardduty
 
 import (
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
 	"github.com/google/uuid"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// inactiveRelationshipStatuses are the relationship statuses of the member accounts that are no longer associated with
+// the GuardDuty Administrator account.
+var inactiveRelationshipStatuses = []string{"Removed", "Resigned", "Disabled", "EmailVerificationFailed", "RegionDisabled", "AccountSuspended"}
+
 func ResourceAwsUtilsGuardDutyOrganizationSettings() *schema.Resource {
 	return &schema.Resource{
 		Description: `Enables a list of accounts as GuardDuty member accounts in an existing AWS Organization.
 
 Designating an account as the GuardDuty Administrator account in an AWS Organization can optionally enable all 
 newly created accounts and accounts that join the organization after the setting is enabled, however it does not 
-enable existing accounts. Use this resource to enable a list of existing accounts`,
+enable existing accounts. Use this resource to enable a list of existing accounts.
+
+Accounts of ` + "`member_accounts`" + ` that are removed from GuardDuty outside of Terraform are detected and added back.
+Members that are not in ` + "`member_accounts`" + `, such as automatically enabled accounts, are listed in ` + "`members`" + `
+but are not removed. When imported by detector ID, ` + "`member_accounts`" + ` is set to all the associated members.`,
 		Create:        resourceAwsGuardDutyOrganizationSettingsCreate,
 		Read:          resourceAwsGuardDutyOrganizationSettingsRead,
 		Update:        resourceAwsGuardDutyOrganizationSettingsUpdate,
 		Delete:        resourceAwsGuardDutyOrganizationSettingsDelete,
 		SchemaVersion: 1,
+		Importer: &schema.ResourceImporter{
+			State: resourceAwsGuardDutyOrganizationSettingsImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of this resource.",
@@ -43,6 +57,24 @@ enable existing accounts. Use this resource to enable a list of existing account
 				ForceNew:     true,
 				ValidateFunc: validation.NoZeroValues,
 			},
+			"members": {
+				Description: "All the member accounts of the GuardDuty Administrator account, including the accounts " +
+					"that are not in `member_accounts`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"relationship_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -67,9 +99,81 @@ func resourceAwsGuardDutyOrganizationSettingsCreate(d *schema.ResourceData, meta
 }
 
 func resourceAwsGuardDutyOrganizationSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).GuardDutyConn
+	detectorID := d.Get("detector_id").(string)
+
+	members, err := FindMembers(conn, detectorID)
+
+	if !d.IsNewResource() && tfawserr.ErrMessageContains(err, guardduty.ErrCodeBadRequestException, "not owned by the current account") {
+		log.Printf("[WARN] GuardDuty detector (%s) not found, removing from state", detectorID)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading guardduty administrator account members: %s", err)
+	}
+
+	active := make(map[string]bool, len(members))
+	tfList := make([]interface{}, 0, len(members))
+
+	for _, member := range members {
+		accountID := aws.StringValue(member.AccountId)
+		status := aws.StringValue(member.RelationshipStatus)
+
+		if isActiveGuardDutyRelationshipStatus(status) {
+			active[accountID] = true
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"account_id":          accountID,
+			"relationship_status": status,
+		})
+	}
+
+	if err := d.Set("members", tfList); err != nil {
+		return fmt.Errorf("error setting members: %s", err)
+	}
+
+	memberAccounts := getMemberAccounts(d)
+
+	// A member account that is no longer associated is removed from the state, so that it is added back.
+	var associated []string
+	for _, accountID := range memberAccounts {
+		if !active[accountID] {
+			log.Printf("[WARN] Account %s is no longer a GuardDuty member", accountID)
+			continue
+		}
+
+		associated = append(associated, accountID)
+	}
+
+	if err := d.Set("member_accounts", associated); err != nil {
+		return fmt.Errorf("error setting member_accounts: %s", err)
+	}
+
 	return nil
 }
 
+// resourceAwsGuardDutyOrganizationSettingsImport imports the organization settings of a detector, which then manage all
+// the members that are associated with it.
+func resourceAwsGuardDutyOrganizationSettingsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.AWSClient).GuardDutyConn
+	detectorID := d.Id()
+
+	members, err := FindMembers(conn, detectorID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading guardduty administrator account members: %s", err)
+	}
+
+	d.Set("detector_id", detectorID)
+	if err := d.Set("member_accounts", activeGuardDutyMemberAccounts(members)); err != nil {
+		return nil, fmt.Errorf("error setting member_accounts: %s", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAwsGuardDutyOrganizationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).GuardDutyConn
 	detectorID := d.Get("detector_id").(string)
@@ -94,7 +198,8 @@ func resourceAwsGuardDutyOrganizationSettingsUpdate(d *schema.ResourceData, meta
 			}
 		}
 	}
-	return nil
+
+	return resourceAwsGuardDutyOrganizationSettingsRead(d, meta)
 }
 
 func resourceAwsGuardDutyOrganizationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
@@ -108,6 +213,27 @@ func resourceAwsGuardDutyOrganizationSettingsDelete(d *schema.ResourceData, meta
 	return nil
 }
 
+func isActiveGuardDutyRelationshipStatus(status string) bool {
+	for _, inactive := range inactiveRelationshipStatuses {
+		if strings.EqualFold(status, inactive) {
+			return false
+		}
+	}
+	return true
+}
+
+// activeGuardDutyMemberAccounts returns the IDs of the members that are still associated with the GuardDuty
+// Administrator account.
+func activeGuardDutyMemberAccounts(members []*guardduty.Member) []string {
+	var accountIDs []string
+	for _, member := range members {
+		if isActiveGuardDutyRelationshipStatus(aws.StringValue(member.RelationshipStatus)) {
+			accountIDs = append(accountIDs, aws.StringValue(member.AccountId))
+		}
+	}
+	return accountIDs
+}
+
 func makeGuardDutyAccountDetails(accounts []string) []*guardduty.AccountDetail {
 	accountDetails := make([]*guardduty.AccountDetail, 0)
 	for i := range accounts {
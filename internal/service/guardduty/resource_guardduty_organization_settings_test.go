@@ -0,0 +1,58 @@
+package guardduty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+)
+
+func TestIsActiveGuardDutyRelationshipStatus(t *testing.T) {
+	testCases := []struct {
+		Status   string
+		Expected bool
+	}{
+		{Status: "Enabled", Expected: true},
+		{Status: "Invited", Expected: true},
+		{Status: "Created", Expected: true},
+		{Status: "Removed"},
+		{Status: "Resigned"},
+		{Status: "Disabled"},
+		{Status: "EmailVerificationFailed"},
+		{Status: "RegionDisabled"},
+		{Status: "AccountSuspended"},
+		{Status: "regiondisabled"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Status, func(t *testing.T) {
+			if got := isActiveGuardDutyRelationshipStatus(testCase.Status); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestActiveGuardDutyMemberAccounts(t *testing.T) {
+	member := func(accountID, status string) *guardduty.Member {
+		return &guardduty.Member{
+			AccountId:          aws.String(accountID),
+			RelationshipStatus: aws.String(status),
+		}
+	}
+
+	members := []*guardduty.Member{
+		member("111111111111", "Enabled"),
+		member("222222222222", "Disabled"),
+		member("333333333333", "Invited"),
+		member("444444444444", "RegionDisabled"),
+		member("555555555555", "Removed"),
+	}
+
+	expected := []string{"111111111111", "333333333333"}
+
+	if got := activeGuardDutyMemberAccounts(members); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -6,25 +6,52 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
-	"math"
+	"log"
+	"regexp"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	deletionProtectionActionError = "error"
+	deletionProtectionActionWarn  = "warn"
+)
+
+func deletionProtectionAction_Values() []string {
+	return []string{
+		deletionProtectionActionError,
+		deletionProtectionActionWarn,
+	}
+}
+
 func ResourceExpiringAccessKey() *schema.Resource {
 	return &schema.Resource{
-		Description: `Provides an IAM access key that expires after max_age seconds. This is a set of credentials that allow API requests to be made as an IAM user.`,
-		Create:      resourceAccessKeyCreate,
-		Read:        resourceAccessKeyRead,
-		Update:      resourceAccessKeyUpdate,
-		Delete:      resourceAccessKeyDelete,
+		Description: `Provides an IAM access key that expires after max_age seconds. This is a set of credentials that allow API requests to be made as an IAM user.
+
+By default, the access key is replaced once it expires, and the expired key is deleted right away. When ` + "`rotation_grace_period`" + ` is set,
+a new access key is created in place instead, and the expired key is kept as ` + "`previous_access_key_id`" + ` for the grace period
+so that consumers can switch to the new credentials without downtime. IAM allows at most two access keys per user,
+so no other access keys may exist for the user when rotating.
+
+Use ` + "`pgp_key`" + `, ` + "`pgp_keys`" + ` or ` + "`age_recipients`" + ` to store the secret encrypted instead of in plaintext, or
+` + "`destination`" + ` to write the credentials to Secrets Manager or SSM Parameter Store on each rotation instead of storing
+the plaintext secret in the state.
+
+Set ` + "`deletion_protection_window`" + ` to refuse, or warn about, deleting an access key that was used recently, and
+` + "`deactivate_before_delete`" + ` to make sure an access key can no longer be used by the time it is deleted.`,
+		Create: resourceAccessKeyCreate,
+		Read:   resourceAccessKeyRead,
+		Update: resourceAccessKeyUpdate,
+		Delete: resourceAccessKeyDelete,
 
 		CustomizeDiff: resourceAccessKeyDiff,
 
@@ -32,9 +59,15 @@ func ResourceExpiringAccessKey() *schema.Resource {
 			// ListAccessKeys requires UserName field in certain scenarios:
 			//   ValidationError: Must specify userName when calling with non-User credentials
 			// To prevent import from requiring this extra information, use GetAccessKeyLastUsed.
+			// The import ID may end with /MAX-AGE-SECONDS, so that the expiration date of an existing access key is
+			// computed from its creation date, and the access key is rotated as soon as it has expired.
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				conn := meta.(*conns.AWSClient).IAMConn
 
+				if err := expiringCredentialImport(d); err != nil {
+					return nil, err
+				}
+
 				input := &iam.GetAccessKeyLastUsedInput{
 					AccessKeyId: aws.String(d.Id()),
 				}
@@ -55,15 +88,48 @@ func ResourceExpiringAccessKey() *schema.Resource {
 			},
 		},
 
-		Schema: map[string]*schema.Schema{
-			"create_date": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"expiration_date": {
-				Type:     schema.TypeString,
-				Computed: true,
+		Schema: expiringCredentialSchema(map[string]*schema.Schema{
+			"age_recipients": {
+				Description: "A list of age X25519 recipients (`age1...`) to encrypt the secret and the SES SMTP password for, instead of storing them in plaintext. " +
+					"Any of the matching identities can decrypt the base64-encoded values with `age --decrypt`.",
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString, ValidateFunc: validateAgeRecipient},
+				Optional: true,
 				ForceNew: true,
+				MinItems: 1,
+				ConflictsWith: []string{
+					"pgp_key",
+					"pgp_keys",
+				},
+			},
+			"deactivate_before_delete": {
+				Description: "Set the access key to `Inactive` before deleting it. An access key that is not deleted because it was used " +
+					"within `deletion_protection_window` is left unchanged.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"deletion_protection_action": {
+				Description:  "What to do when an access key about to be deleted was used within `deletion_protection_window`. Valid values are `error`, which refuses to delete it, and `warn`, which deletes it and logs a warning.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      deletionProtectionActionError,
+				ValidateFunc: validation.StringInSlice(deletionProtectionAction_Values(), false),
+			},
+			"deletion_protection_window": {
+				Description: "The number of seconds before deletion during which use of the access key, as reported by `GetAccessKeyLastUsed`, " +
+					"triggers `deletion_protection_action`. Also applies to the previous access key at the end of the rotation grace period. " +
+					"Note that AWS may take a few hours to report the last use of an access key.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"destination": accessKeyDestinationSchema(),
+			"destination_version": {
+				Description: "The version of the Secrets Manager secret or SSM parameter holding the current access key.",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"encrypted_secret": {
 				Type:     schema.TypeString,
@@ -73,20 +139,83 @@ func ResourceExpiringAccessKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"encrypted_ses_smtp_passwords_v4": {
+				Description: "The encrypted SES SMTP passwords, by region of `ses_smtp_regions`.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
 			"key_fingerprint": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"max_age": {
-				Type:     schema.TypeInt,
-				ForceNew: true,
-				Optional: true,
-				Default:  math.MaxInt32,
+			"key_fingerprints": {
+				Description: "The fingerprints of the PGP keys, or the age recipients, the secret is encrypted for, in the configured order.",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"last_used_date": {
+				Description: "The date the access key was last used, if it was ever used.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_used_region": {
+				Description: "The region in which the access key was last used.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"last_used_service": {
+				Description: "The name of the AWS service with which the access key was last used.",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"pgp_key": {
 				Type:     schema.TypeString,
 				ForceNew: true,
 				Optional: true,
+				ConflictsWith: []string{
+					"age_recipients",
+					"pgp_keys",
+				},
+			},
+			"pgp_keys": {
+				Description: "A list of base64-encoded PGP public keys, or `keybase:` usernames, to encrypt the secret and the SES SMTP password for, " +
+					"instead of storing them in plaintext. Any of the matching private keys can decrypt the values.",
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				ForceNew: true,
+				MinItems: 1,
+				ConflictsWith: []string{
+					"age_recipients",
+					"pgp_key",
+				},
+			},
+			"previous_access_key_expiration_date": {
+				Description: "The date after which the previous access key is deleted.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_access_key_id": {
+				Description: "The ID of the access key that was rotated out. It is kept until `previous_access_key_expiration_date`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"previous_key_status": {
+				Description:  "The status of the previous access key during the rotation grace period. Valid values are `Active` and `Inactive`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      iam.StatusTypeActive,
+				ValidateFunc: validation.StringInSlice(iam.StatusType_Values(), false),
+			},
+			"rotation_grace_period": {
+				Description: "The number of seconds to keep the previous access key after the access key is rotated. " +
+					"When set, the access key is rotated in place instead of being replaced once it expires. Must be less than `max_age`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"secret": {
 				Type:      schema.TypeString,
@@ -98,6 +227,24 @@ func ResourceExpiringAccessKey() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"ses_smtp_passwords_v4": {
+				Description: "The SES SMTP passwords, by region of `ses_smtp_regions`.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"ses_smtp_regions": {
+				Description: "A list of regions to derive SES SMTP passwords for, in addition to the region of the provider. " +
+					"The SMTP password of an access key differs by region.",
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(sesSMTPRegionRegexp, "must be an AWS region name, e.g. us-east-1"),
+				},
+				Optional: true,
+				ForceNew: true,
+			},
 			"status": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -109,31 +256,86 @@ func ResourceExpiringAccessKey() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
-		},
+		}),
+	}
+}
+
+func validateAgeRecipient(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := ParseAgeRecipient(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
 	}
+	return
 }
 
 func resourceAccessKeyDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	gracePeriod := d.Get("rotation_grace_period").(int)
+
+	if gracePeriod > 0 && gracePeriod >= d.Get("max_age").(int) {
+		return fmt.Errorf("rotation_grace_period (%d) must be less than max_age (%d) so that the user never needs more than two access keys", gracePeriod, d.Get("max_age").(int))
+	}
+
 	if d.Id() == "" {
 		return nil
 	}
 
-	if v, ok := d.GetOk("expiration_date"); ok {
-		now := time.Now().UTC()
-		rotationTimestamp, err := time.Parse(time.RFC3339, v.(string))
+	now := time.Now().UTC()
+
+	if v, ok := d.GetOk("previous_access_key_expiration_date"); ok {
+		previousExpirationTimestamp, err := time.Parse(time.RFC3339, v.(string))
 
 		if err != nil {
-			return fmt.Errorf("error parsing expiration_date (%s): %s", v.(string), err)
+			return fmt.Errorf("error parsing previous_access_key_expiration_date (%s): %s", v.(string), err)
 		}
 
-		if now.After(rotationTimestamp) {
-			d.SetNewComputed("expiration_date")
+		if now.After(previousExpirationTimestamp) {
+			d.SetNewComputed("previous_access_key_id")
+			d.SetNewComputed("previous_access_key_expiration_date")
+		}
+	}
+
+	expired, err := expiringCredentialExpired(d.Get("expiration_date").(string))
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		if gracePeriod == 0 {
+			return resourceExpiringCredentialDiff(ctx, d, meta)
+		}
+
+		// The access key is rotated in place by Update.
+		for _, k := range []string{
+			"create_date",
+			"destination_version",
+			"encrypted_secret",
+			"encrypted_ses_smtp_password_v4",
+			"encrypted_ses_smtp_passwords_v4",
+			"expiration_date",
+			"previous_access_key_expiration_date",
+			"previous_access_key_id",
+			"secret",
+			"ses_smtp_password_v4",
+			"ses_smtp_passwords_v4",
+		} {
+			d.SetNewComputed(k)
 		}
 	}
 	return nil
 }
 
 func resourceAccessKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	key, err := resourceAccessKeyCreateKey(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return resourceAccessKeyReadResult(d, key)
+}
+
+// resourceAccessKeyCreateKey creates an access key for the user, sets the secret attributes and applies the
+// configured status. The ID of the resource is set to the new key as soon as it is created, so that the key is
+// tracked even when a later step fails.
+func resourceAccessKeyCreateKey(d *schema.ResourceData, meta interface{}) (*iam.AccessKeyMetadata, error) {
 	conn := meta.(*conns.AWSClient).IAMConn
 
 	request := &iam.CreateAccessKeyInput{
@@ -142,76 +344,161 @@ func resourceAccessKeyCreate(d *schema.ResourceData, meta interface{}) error {
 
 	createResp, err := conn.CreateAccessKey(request)
 	if err != nil {
-		return fmt.Errorf(
+		if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == iam.ErrCodeLimitExceededException {
+			return nil, fmt.Errorf(
+				"Error creating access key for user %s: IAM allows at most two access keys per user, delete any access key not managed by this resource: %s",
+				*request.UserName,
+				err,
+			)
+		}
+		return nil, fmt.Errorf(
 			"Error creating access key for user %s: %s",
 			*request.UserName,
 			err,
 		)
 	}
 
+	if createResp.AccessKey == nil {
+		return nil, fmt.Errorf("CreateAccessKey response did not contain an Access Key as expected")
+	}
+
 	d.SetId(aws.StringValue(createResp.AccessKey.AccessKeyId))
 
-	if createResp.AccessKey == nil || createResp.AccessKey.SecretAccessKey == nil {
-		return fmt.Errorf("CreateAccessKey response did not contain a Secret Access Key as expected")
+	if createResp.AccessKey.SecretAccessKey == nil {
+		return nil, fmt.Errorf("CreateAccessKey response did not contain a Secret Access Key as expected")
 	}
 
 	sesSMTPPasswordV4, err := SessmTPPasswordFromSecretKeySigV4(createResp.AccessKey.SecretAccessKey, meta.(*conns.AWSClient).Region)
 	if err != nil {
-		return fmt.Errorf("error getting SES SigV4 SMTP Password from Secret Access Key: %s", err)
+		return nil, fmt.Errorf("error getting SES SigV4 SMTP Password from Secret Access Key: %s", err)
 	}
 
-	if v, ok := d.GetOk("pgp_key"); ok {
-		pgpKey := v.(string)
-		encryptionKey, err := RetrieveGPGKey(pgpKey)
-		if err != nil {
-			return err
-		}
-		fingerprint, encrypted, err := EncryptValue(encryptionKey, *createResp.AccessKey.SecretAccessKey, "IAM Access Key Secret")
+	sesSMTPPasswordsV4, err := sesSMTPPasswordsFromSecretKeySigV4(createResp.AccessKey.SecretAccessKey, flex.ExpandStringSliceofPointers(flex.ExpandStringList(d.Get("ses_smtp_regions").([]interface{}))))
+	if err != nil {
+		return nil, fmt.Errorf("error getting SES SigV4 SMTP Passwords from Secret Access Key: %s", err)
+	}
+
+	encrypt, err := accessKeyEncrypter(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if encrypt != nil {
+		fingerprints, encrypted, err := encrypt(*createResp.AccessKey.SecretAccessKey, "IAM Access Key Secret")
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		d.Set("key_fingerprint", fingerprint)
+		d.Set("key_fingerprint", fingerprints[0])
+		d.Set("key_fingerprints", fingerprints)
 		d.Set("encrypted_secret", encrypted)
 
-		_, encrypted, err = EncryptValue(encryptionKey, sesSMTPPasswordV4, "SES SMTP password")
+		_, encrypted, err = encrypt(sesSMTPPasswordV4, "SES SMTP password")
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		d.Set("encrypted_ses_smtp_password_v4", encrypted)
-	} else {
+
+		encryptedPasswords := make(map[string]string, len(sesSMTPPasswordsV4))
+		for region, password := range sesSMTPPasswordsV4 {
+			_, encrypted, err := encrypt(password, fmt.Sprintf("SES SMTP password (%s)", region))
+			if err != nil {
+				return nil, err
+			}
+
+			encryptedPasswords[region] = encrypted
+		}
+
+		d.Set("encrypted_ses_smtp_passwords_v4", encryptedPasswords)
+	} else if _, ok := d.GetOk("destination"); !ok {
 		if err := d.Set("secret", createResp.AccessKey.SecretAccessKey); err != nil {
-			return err
+			return nil, err
 		}
 
 		if err := d.Set("ses_smtp_password_v4", sesSMTPPasswordV4); err != nil {
-			return err
+			return nil, err
 		}
-	}
 
-	if v, ok := d.GetOk("status"); ok && v.(string) == iam.StatusTypeInactive {
-		input := &iam.UpdateAccessKeyInput{
-			AccessKeyId: aws.String(d.Id()),
-			Status:      aws.String(iam.StatusTypeInactive),
-			UserName:    aws.String(d.Get("user").(string)),
+		if err := d.Set("ses_smtp_passwords_v4", sesSMTPPasswordsV4); err != nil {
+			return nil, err
 		}
+	}
 
-		_, err := conn.UpdateAccessKey(input)
+	if _, ok := d.GetOk("destination"); ok {
+		err := writeAccessKeyDestination(d, meta, &accessKeyDestinationValue{
+			AccessKeyID:        aws.StringValue(createResp.AccessKey.AccessKeyId),
+			SecretAccessKey:    aws.StringValue(createResp.AccessKey.SecretAccessKey),
+			SESSMTPPasswordV4:  sesSMTPPasswordV4,
+			SESSMTPPasswordsV4: sesSMTPPasswordsV4,
+		})
 
 		if err != nil {
-			return fmt.Errorf("error deactivating IAM Access Key (%s): %w", d.Id(), err)
+			return nil, err
+		}
+	}
+
+	if v, ok := d.GetOk("status"); ok && v.(string) == iam.StatusTypeInactive {
+		if err := updateAccessKeyStatus(conn, aws.StringValue(createResp.AccessKey.AccessKeyId), d.Get("user").(string), iam.StatusTypeInactive); err != nil {
+			return nil, err
 		}
 
 		createResp.AccessKey.Status = aws.String(iam.StatusTypeInactive)
 	}
 
-	return resourceAccessKeyReadResult(d, &iam.AccessKeyMetadata{
+	return &iam.AccessKeyMetadata{
 		AccessKeyId: createResp.AccessKey.AccessKeyId,
 		CreateDate:  createResp.AccessKey.CreateDate,
 		Status:      createResp.AccessKey.Status,
 		UserName:    createResp.AccessKey.UserName,
-	})
+	}, nil
+}
+
+// accessKeyEncrypter returns a function that encrypts a value for the configured `pgp_key`, `pgp_keys` or
+// `age_recipients`, and returns the fingerprints of the recipients along with the encrypted value.
+// Returns nil when no encryption is configured.
+func accessKeyEncrypter(d *schema.ResourceData) (func(value, description string) ([]string, string, error), error) {
+	if v, ok := d.GetOk("pgp_key"); ok {
+		encryptionKey, err := RetrieveGPGKey(v.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(value, description string) ([]string, string, error) {
+			fingerprint, encrypted, err := EncryptValue(encryptionKey, value, description)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return []string{fingerprint}, encrypted, nil
+		}, nil
+	}
+
+	if v, ok := d.GetOk("pgp_keys"); ok && len(v.([]interface{})) > 0 {
+		encryptionKeys, err := RetrieveGPGKeys(flex.ExpandStringSliceofPointers(flex.ExpandStringList(v.([]interface{}))))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(value, description string) ([]string, string, error) {
+			return EncryptValueForRecipients(encryptionKeys, value, description)
+		}, nil
+	}
+
+	if v, ok := d.GetOk("age_recipients"); ok && len(v.([]interface{})) > 0 {
+		recipients := flex.ExpandStringSliceofPointers(flex.ExpandStringList(v.([]interface{})))
+
+		return func(value, description string) ([]string, string, error) {
+			encrypted, err := EncryptAgeValue(recipients, value, description)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return recipients, encrypted, nil
+		}, nil
+	}
+
+	return nil, nil
 }
 
 func resourceAccessKeyRead(d *schema.ResourceData, meta interface{}) error {
@@ -231,12 +518,44 @@ func resourceAccessKeyRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("Error reading IAM access key: %s", err)
 	}
 
+	var current *iam.AccessKeyMetadata
+	previousID := d.Get("previous_access_key_id").(string)
+	previousFound := false
+
 	for _, key := range getResp.AccessKeyMetadata {
 		if key.AccessKeyId != nil && *key.AccessKeyId == d.Id() {
-			return resourceAccessKeyReadResult(d, key)
+			current = key
+		}
+
+		if previousID != "" && aws.StringValue(key.AccessKeyId) == previousID {
+			previousFound = true
+			d.Set("previous_key_status", key.Status)
 		}
 	}
 
+	if current != nil {
+		if previousID != "" && !previousFound {
+			log.Printf("[WARN] Previous IAM Access Key (%s) not found, removing from state", previousID)
+			d.Set("previous_access_key_id", "")
+			d.Set("previous_access_key_expiration_date", "")
+		}
+
+		lastUsed, err := findAccessKeyLastUsed(conn, d.Id())
+		if err != nil {
+			return err
+		}
+
+		if lastUsed.LastUsedDate != nil {
+			d.Set("last_used_date", aws.TimeValue(lastUsed.LastUsedDate).Format(time.RFC3339))
+		} else {
+			d.Set("last_used_date", nil)
+		}
+		d.Set("last_used_region", lastUsed.Region)
+		d.Set("last_used_service", lastUsed.ServiceName)
+
+		return resourceAccessKeyReadResult(d, current)
+	}
+
 	// Guess the key isn't around anymore.
 	d.SetId("")
 	return nil
@@ -245,16 +564,7 @@ func resourceAccessKeyRead(d *schema.ResourceData, meta interface{}) error {
 func resourceAccessKeyReadResult(d *schema.ResourceData, key *iam.AccessKeyMetadata) error {
 	d.SetId(aws.StringValue(key.AccessKeyId))
 
-	if key.CreateDate != nil {
-		createDate := key.CreateDate
-		expirationDate := createDate.Add(time.Duration(d.Get("max_age").(int)) * time.Second)
-
-		d.Set("create_date", aws.TimeValue(createDate).Format(time.RFC3339))
-		d.Set("expiration_date", expirationDate.Format(time.RFC3339))
-	} else {
-		d.Set("create_date", nil)
-		d.Set("expiration_date", nil)
-	}
+	setExpiringCredentialDates(d, key.CreateDate)
 
 	d.Set("status", key.Status)
 	d.Set("user", key.UserName)
@@ -271,32 +581,214 @@ func resourceAccessKeyUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if err := resourceAccessKeyRotate(d, meta); err != nil {
+		return err
+	}
+
 	return resourceAccessKeyRead(d, meta)
 }
 
-func resourceAccessKeyDelete(d *schema.ResourceData, meta interface{}) error {
+// resourceAccessKeyRotate deletes the previous access key once its grace period is over and, when
+// `rotation_grace_period` is set, replaces the access key with a new one in place once it expires.
+func resourceAccessKeyRotate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).IAMConn
+	now := time.Now().UTC()
+	user := d.Get("user").(string)
+
+	// These are unknown in the plan when they are about to change, so use the prior state.
+	o, _ := d.GetChange("previous_access_key_id")
+	previousID := o.(string)
+	o, _ = d.GetChange("previous_access_key_expiration_date")
+	previousExpirationDate := o.(string)
+	o, _ = d.GetChange("expiration_date")
+	expirationDate := o.(string)
+
+	if previousID != "" {
+		previousExpirationTimestamp, err := time.Parse(time.RFC3339, previousExpirationDate)
+		if err != nil {
+			return fmt.Errorf("error parsing previous_access_key_expiration_date (%s): %s", previousExpirationDate, err)
+		}
 
-	request := &iam.DeleteAccessKeyInput{
-		AccessKeyId: aws.String(d.Id()),
-		UserName:    aws.String(d.Get("user").(string)),
+		if now.After(previousExpirationTimestamp) {
+			warning, err := retireAccessKey(conn, d, previousID, user)
+			if err != nil {
+				return err
+			}
+
+			if warning != "" {
+				log.Printf("[WARN] %s", warning)
+			}
+
+			previousID = ""
+			previousExpirationDate = ""
+		} else if d.HasChange("previous_key_status") {
+			if err := updateAccessKeyStatus(conn, previousID, user, d.Get("previous_key_status").(string)); err != nil {
+				return err
+			}
+		}
 	}
 
-	if _, err := conn.DeleteAccessKey(request); err != nil {
-		return fmt.Errorf("Error deleting access key %s: %s", d.Id(), err)
+	d.Set("previous_access_key_id", previousID)
+	d.Set("previous_access_key_expiration_date", previousExpirationDate)
+
+	gracePeriod := d.Get("rotation_grace_period").(int)
+
+	if gracePeriod == 0 {
+		return nil
 	}
+
+	expired, err := expiringCredentialExpired(expirationDate)
+	if err != nil {
+		return err
+	}
+
+	if !expired {
+		return nil
+	}
+
+	if previousID != "" {
+		return fmt.Errorf("cannot rotate IAM Access Key (%s) as the previous access key (%s) is still within its rotation grace period, "+
+			"and IAM allows at most two access keys per user", d.Id(), previousID)
+	}
+
+	rotatedID := d.Id()
+
+	key, err := resourceAccessKeyCreateKey(d, meta)
+	if err != nil {
+		// The new key is tracked from now on, keep the rotated one as the previous key so that it is retired.
+		if d.Id() != rotatedID {
+			d.Set("previous_access_key_id", rotatedID)
+			d.Set("previous_access_key_expiration_date", now.Add(time.Duration(gracePeriod)*time.Second).Format(time.RFC3339))
+		}
+
+		return fmt.Errorf("error rotating IAM Access Key (%s): %w", rotatedID, err)
+	}
+
+	if previousKeyStatus := d.Get("previous_key_status").(string); previousKeyStatus != iam.StatusTypeActive {
+		if err := updateAccessKeyStatus(conn, rotatedID, user, previousKeyStatus); err != nil {
+			return err
+		}
+	}
+
+	previousExpirationTimestamp := aws.TimeValue(key.CreateDate).Add(time.Duration(gracePeriod) * time.Second)
+
+	d.Set("previous_access_key_id", rotatedID)
+	d.Set("previous_access_key_expiration_date", previousExpirationTimestamp.Format(time.RFC3339))
+
+	return resourceAccessKeyReadResult(d, key)
+}
+
+func resourceAccessKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+	user := d.Get("user").(string)
+
+	for _, id := range []string{d.Get("previous_access_key_id").(string), d.Id()} {
+		if id == "" {
+			continue
+		}
+
+		warning, err := retireAccessKey(conn, d, id, user)
+		if err != nil {
+			return err
+		}
+
+		if warning != "" {
+			log.Printf("[WARN] %s", warning)
+		}
+	}
+
 	return nil
 }
 
+// retireAccessKey deletes an access key of the user, honoring `deletion_protection_window` and `deactivate_before_delete`.
+// A warning is returned instead of an error when the access key was used recently and `deletion_protection_action` is `warn`.
+// An access key that is not deleted is left unchanged.
+func retireAccessKey(conn *iam.IAM, d *schema.ResourceData, id, user string) (string, error) {
+	var warning string
+
+	if window := d.Get("deletion_protection_window").(int); window > 0 {
+		lastUsed, err := findAccessKeyLastUsed(conn, id)
+
+		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			return "", nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		if lastUsed.LastUsedDate != nil && time.Since(aws.TimeValue(lastUsed.LastUsedDate)) < time.Duration(window)*time.Second {
+			message := fmt.Sprintf("IAM Access Key (%s) was last used on %s with %s in %s, within the deletion_protection_window of %d seconds",
+				id, aws.TimeValue(lastUsed.LastUsedDate).Format(time.RFC3339), aws.StringValue(lastUsed.ServiceName), aws.StringValue(lastUsed.Region), window)
+
+			if d.Get("deletion_protection_action").(string) == deletionProtectionActionError {
+				return "", fmt.Errorf("refusing to delete %s", message)
+			}
+
+			warning = message
+		}
+	}
+
+	if d.Get("deactivate_before_delete").(bool) {
+		err := updateAccessKeyStatus(conn, id, user, iam.StatusTypeInactive)
+
+		if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+			return "", nil
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return warning, deleteAccessKey(conn, id, user)
+}
+
 func resourceAccessKeyStatusUpdate(conn *iam.IAM, d *schema.ResourceData) error {
+	return updateAccessKeyStatus(conn, d.Id(), d.Get("user").(string), d.Get("status").(string))
+}
+
+func updateAccessKeyStatus(conn *iam.IAM, id, user, status string) error {
 	request := &iam.UpdateAccessKeyInput{
-		AccessKeyId: aws.String(d.Id()),
-		Status:      aws.String(d.Get("status").(string)),
-		UserName:    aws.String(d.Get("user").(string)),
+		AccessKeyId: aws.String(id),
+		Status:      aws.String(status),
+		UserName:    aws.String(user),
 	}
 
 	if _, err := conn.UpdateAccessKey(request); err != nil {
-		return fmt.Errorf("Error updating access key %s: %s", d.Id(), err)
+		return fmt.Errorf("Error updating access key %s: %w", id, err)
+	}
+	return nil
+}
+
+func findAccessKeyLastUsed(conn *iam.IAM, id string) (*iam.AccessKeyLastUsed, error) {
+	output, err := conn.GetAccessKeyLastUsed(&iam.GetAccessKeyLastUsedInput{
+		AccessKeyId: aws.String(id),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading IAM Access Key (%s) last used: %w", id, err)
+	}
+
+	if output == nil || output.AccessKeyLastUsed == nil {
+		return nil, fmt.Errorf("error reading IAM Access Key (%s) last used: empty response", id)
+	}
+
+	return output.AccessKeyLastUsed, nil
+}
+
+// deleteAccessKey deletes an access key that may already be gone.
+func deleteAccessKey(conn *iam.IAM, id, user string) error {
+	request := &iam.DeleteAccessKeyInput{
+		AccessKeyId: aws.String(id),
+		UserName:    aws.String(user),
+	}
+
+	if _, err := conn.DeleteAccessKey(request); err != nil {
+		if iamerr, ok := err.(awserr.Error); ok && iamerr.Code() == iam.ErrCodeNoSuchEntityException {
+			return nil
+		}
+		return fmt.Errorf("Error deleting access key %s: %s", id, err)
 	}
 	return nil
 }
@@ -342,3 +834,22 @@ func SessmTPPasswordFromSecretKeySigV4(key *string, region string) (string, erro
 	versionedSig = append(versionedSig, rawSig...)
 	return base64.StdEncoding.EncodeToString(versionedSig), nil
 }
+
+// sesSMTPRegionRegexp matches the name of an AWS region.
+var sesSMTPRegionRegexp = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// sesSMTPPasswordsFromSecretKeySigV4 returns the SES SigV4 SMTP password of the secret access key for each region.
+func sesSMTPPasswordsFromSecretKeySigV4(key *string, regions []string) (map[string]string, error) {
+	passwords := make(map[string]string, len(regions))
+
+	for _, region := range regions {
+		password, err := SessmTPPasswordFromSecretKeySigV4(key, region)
+		if err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+
+		passwords[region] = password
+	}
+
+	return passwords, nil
+}
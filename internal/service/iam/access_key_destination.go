@@ -0,0 +1,175 @@
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	accessKeyDestinationTypeSecretsManager = "secretsmanager"
+	accessKeyDestinationTypeSSM            = "ssm"
+)
+
+func accessKeyDestinationType_Values() []string {
+	return []string{
+		accessKeyDestinationTypeSecretsManager,
+		accessKeyDestinationTypeSSM,
+	}
+}
+
+func accessKeyDestinationSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Write the access key ID, secret and SES SMTP passwords to a Secrets Manager secret or an SSM SecureString parameter " +
+			"whenever an access key is created or rotated. When set, `secret`, `ses_smtp_password_v4` and `ses_smtp_passwords_v4` are not stored in the state. " +
+			"The secret or parameter is not deleted when the access key is destroyed.",
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"kms_key_id": {
+					Description: "The KMS key used to encrypt the secret or parameter. An existing secret is switched to this key. Defaults to the AWS managed key of the service.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					ForceNew:    true,
+				},
+				"name": {
+					Description: "The name or ARN of the Secrets Manager secret, or the name of the SSM parameter. It is created if it does not exist.",
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+				},
+				"type": {
+					Description:  "The type of the destination. Valid values are `secretsmanager` and `ssm`.",
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringInSlice(accessKeyDestinationType_Values(), false),
+				},
+			},
+		},
+	}
+}
+
+// accessKeyDestinationValue is the JSON document written to the destination.
+type accessKeyDestinationValue struct {
+	AccessKeyID        string            `json:"AccessKeyId"`
+	SecretAccessKey    string            `json:"SecretAccessKey"`
+	SESSMTPPasswordV4  string            `json:"SesSmtpPasswordV4"`
+	SESSMTPPasswordsV4 map[string]string `json:"SesSmtpPasswordsV4,omitempty"`
+}
+
+// writeAccessKeyDestination writes the credentials to the configured destination and records the resulting version.
+func writeAccessKeyDestination(d *schema.ResourceData, meta interface{}, value *accessKeyDestinationValue) error {
+	tfMap := d.Get("destination").([]interface{})[0].(map[string]interface{})
+
+	document, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error encoding IAM Access Key (%s) destination value: %w", value.AccessKeyID, err)
+	}
+
+	name := tfMap["name"].(string)
+	kmsKeyID := tfMap["kms_key_id"].(string)
+
+	var version string
+
+	switch tfMap["type"].(string) {
+	case accessKeyDestinationTypeSecretsManager:
+		version, err = putAccessKeySecretValue(meta.(*conns.AWSClient).SecretsManagerConn, name, kmsKeyID, string(document))
+	case accessKeyDestinationTypeSSM:
+		version, err = putAccessKeyParameter(meta.(*conns.AWSClient).SSMConn, name, kmsKeyID, string(document))
+	}
+
+	if err != nil {
+		return fmt.Errorf("error writing IAM Access Key (%s) to %s destination (%s): %w", value.AccessKeyID, tfMap["type"].(string), name, err)
+	}
+
+	return d.Set("destination_version", version)
+}
+
+// putAccessKeySecretValue stores value as a new version of the secret, creating the secret if it does not exist.
+// When kmsKeyID is set, an existing secret is switched to that KMS key, which encrypts the new version.
+func putAccessKeySecretValue(conn *secretsmanager.SecretsManager, name, kmsKeyID, value string) (string, error) {
+	var versionID *string
+	var err error
+
+	if kmsKeyID != "" {
+		var output *secretsmanager.UpdateSecretOutput
+		output, err = conn.UpdateSecret(&secretsmanager.UpdateSecretInput{
+			KmsKeyId:     aws.String(kmsKeyID),
+			SecretId:     aws.String(name),
+			SecretString: aws.String(value),
+		})
+
+		if err == nil {
+			versionID = output.VersionId
+		}
+	} else {
+		var output *secretsmanager.PutSecretValueOutput
+		output, err = conn.PutSecretValue(&secretsmanager.PutSecretValueInput{
+			SecretId:     aws.String(name),
+			SecretString: aws.String(value),
+		})
+
+		if err == nil {
+			versionID = output.VersionId
+		}
+	}
+
+	if tfawserr.ErrCodeEquals(err, secretsmanager.ErrCodeResourceNotFoundException) {
+		input := &secretsmanager.CreateSecretInput{
+			Description:  aws.String("IAM access key managed by awsutils_expiring_iam_access_key"),
+			Name:         aws.String(name),
+			SecretString: aws.String(value),
+		}
+
+		if kmsKeyID != "" {
+			input.KmsKeyId = aws.String(kmsKeyID)
+		}
+
+		createOutput, err := conn.CreateSecret(input)
+		if err != nil {
+			return "", err
+		}
+
+		return aws.StringValue(createOutput.VersionId), nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(versionID), nil
+}
+
+// putAccessKeyParameter stores value in the SecureString parameter, creating or overwriting it.
+func putAccessKeyParameter(conn *ssm.SSM, name, kmsKeyID, value string) (string, error) {
+	input := &ssm.PutParameterInput{
+		Description: aws.String("IAM access key managed by awsutils_expiring_iam_access_key"),
+		Name:        aws.String(name),
+		Overwrite:   aws.Bool(true),
+		Type:        aws.String(ssm.ParameterTypeSecureString),
+		Value:       aws.String(value),
+	}
+
+	if kmsKeyID != "" {
+		input.KeyId = aws.String(kmsKeyID)
+	}
+
+	output, err := conn.PutParameter(input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(aws.Int64Value(output.Version), 10), nil
+}
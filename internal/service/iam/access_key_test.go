@@ -0,0 +1,66 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestSessmTPPasswordFromSecretKeySigV4(t *testing.T) {
+	cases := []struct {
+		Region   string
+		Input    string
+		Expected string
+	}{
+		{"eu-central-1", "some+secret+key", "BMXhUYlu5Z3gSXVQORxlVa7XPaz91aGWdfHxvkOZdWZ2"},
+		{"eu-central-1", "another+secret+key", "BBbphbrQmrKMx42d1N6+C7VINYEBGI5v9VsZeTxwskfh"},
+		{"us-west-1", "some+secret+key", "BH+jbMzper5WwlwUar9E1ySBqHa9whi0GPo+sJ0mVYJj"},
+		{"us-west-1", "another+secret+key", "BKVmjjMDFk/qqw8EROW99bjCS65PF8WKvK5bSr4Y6EqF"},
+	}
+
+	for _, tc := range cases {
+		actual, err := SessmTPPasswordFromSecretKeySigV4(aws.String(tc.Input), tc.Region)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if actual != tc.Expected {
+			t.Fatalf("%q: expected %q, got %q", tc.Input, tc.Expected, actual)
+		}
+	}
+}
+
+func TestSESSMTPPasswordsFromSecretKeySigV4(t *testing.T) {
+	actual, err := sesSMTPPasswordsFromSecretKeySigV4(aws.String("some+secret+key"), []string{"eu-central-1", "us-west-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"eu-central-1": "BMXhUYlu5Z3gSXVQORxlVa7XPaz91aGWdfHxvkOZdWZ2",
+		"us-west-1":    "BH+jbMzper5WwlwUar9E1ySBqHa9whi0GPo+sJ0mVYJj",
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d passwords, got %d", len(expected), len(actual))
+	}
+
+	for region, password := range expected {
+		if actual[region] != password {
+			t.Errorf("%s: expected %q, got %q", region, password, actual[region])
+		}
+	}
+}
+
+func TestSESSMTPRegionRegexp(t *testing.T) {
+	for _, region := range []string{"us-east-1", "eu-central-1", "ap-southeast-2", "us-gov-west-1"} {
+		if !sesSMTPRegionRegexp.MatchString(region) {
+			t.Errorf("expected %s to be a valid region", region)
+		}
+	}
+
+	for _, region := range []string{"", "us-east", "US-EAST-1", "us_east_1"} {
+		if sesSMTPRegionRegexp.MatchString(region) {
+			t.Errorf("expected %s to be an invalid region", region)
+		}
+	}
+}
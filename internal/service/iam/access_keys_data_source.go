@@ -0,0 +1,211 @@
+package iam
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceAccessKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: `Lists the access keys of every IAM user in the account, with their age and the number of days since they were last
+used, to find stale access keys, including those that were not created with ` + "`awsutils_expiring_iam_access_key`" + `.`,
+		Read: dataSourceAccessKeysRead,
+		Schema: map[string]*schema.Schema{
+			"access_keys": {
+				Description: "The access keys that match the filters, ordered by user name and creation date.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_key_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"age_days": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"create_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"days_since_last_use": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"last_used_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_used_region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_used_service": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ids": {
+				Description: "The IDs of the access keys that match the filters.",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"older_than": {
+				Description:  "Only include access keys created at least this many days ago.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"status": {
+				Description:  "Only include access keys with this status. Valid values are `Active` and `Inactive`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(iam.StatusType_Values(), false),
+			},
+			"unused_for": {
+				Description: "Only include access keys that were not used for at least this many days. " +
+					"Access keys that were never used count as unused since they were created.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+		},
+	}
+}
+
+func dataSourceAccessKeysRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+	now := time.Now().UTC()
+
+	var users []*iam.User
+	err := conn.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		users = append(users, page.Users...)
+
+		return !lastPage
+	})
+	if err != nil {
+		return fmt.Errorf("error listing IAM Users: %w", err)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return aws.StringValue(users[i].UserName) < aws.StringValue(users[j].UserName)
+	})
+
+	var keys []*iam.AccessKeyMetadata
+	for _, user := range users {
+		input := &iam.ListAccessKeysInput{
+			UserName: user.UserName,
+		}
+
+		var userKeys []*iam.AccessKeyMetadata
+		err := conn.ListAccessKeysPages(input, func(page *iam.ListAccessKeysOutput, lastPage bool) bool {
+			if page == nil {
+				return !lastPage
+			}
+
+			userKeys = append(userKeys, page.AccessKeyMetadata...)
+
+			return !lastPage
+		})
+		if err != nil {
+			return fmt.Errorf("error listing IAM Access Keys for user (%s): %w", aws.StringValue(user.UserName), err)
+		}
+
+		sort.Slice(userKeys, func(i, j int) bool {
+			return aws.TimeValue(userKeys[i].CreateDate).Before(aws.TimeValue(userKeys[j].CreateDate))
+		})
+
+		keys = append(keys, userKeys...)
+	}
+
+	status := d.Get("status").(string)
+	olderThan := d.Get("older_than").(int)
+	unusedFor := d.Get("unused_for").(int)
+
+	tfList := make([]interface{}, 0, len(keys))
+	ids := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if status != "" && aws.StringValue(key.Status) != status {
+			continue
+		}
+
+		id := aws.StringValue(key.AccessKeyId)
+		createDate := aws.TimeValue(key.CreateDate)
+		ageDays := daysBetween(createDate, now)
+
+		if ageDays < olderThan {
+			continue
+		}
+
+		lastUsed, err := findAccessKeyLastUsed(conn, id)
+		if err != nil {
+			return err
+		}
+
+		var lastUsedDate string
+		daysSinceLastUse := ageDays
+
+		if lastUsed.LastUsedDate != nil {
+			lastUsedDate = aws.TimeValue(lastUsed.LastUsedDate).Format(time.RFC3339)
+			daysSinceLastUse = daysBetween(aws.TimeValue(lastUsed.LastUsedDate), now)
+		}
+
+		if daysSinceLastUse < unusedFor {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"access_key_id":       id,
+			"age_days":            ageDays,
+			"create_date":         createDate.Format(time.RFC3339),
+			"days_since_last_use": daysSinceLastUse,
+			"last_used_date":      lastUsedDate,
+			"last_used_region":    aws.StringValue(lastUsed.Region),
+			"last_used_service":   aws.StringValue(lastUsed.ServiceName),
+			"status":              aws.StringValue(key.Status),
+			"user_name":           aws.StringValue(key.UserName),
+		})
+		ids = append(ids, id)
+	}
+
+	d.SetId(meta.(*conns.AWSClient).AccountID)
+
+	if err := d.Set("access_keys", tfList); err != nil {
+		return fmt.Errorf("error setting access_keys: %w", err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %w", err)
+	}
+
+	return nil
+}
+
+// daysBetween returns the number of whole days elapsed from start to end.
+func daysBetween(start, end time.Time) int {
+	return int(end.Sub(start) / (24 * time.Hour))
+}
@@ -1,11 +1,13 @@
 package iam
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"strings"
 
 	"github.com/cloudposse/terraform-provider-awsutils/internal/vault/helper/pgpkeys"
+	"github.com/keybase/go-crypto/openpgp"
 )
 
 // RetrieveGPGKey returns the PGP key specified as the pgpKey parameter, or queries
@@ -37,3 +39,50 @@ func EncryptValue(encryptionKey, value, description string) (string, string, err
 
 	return fingerprints[0], base64.StdEncoding.EncodeToString(encryptedValue[0]), nil
 }
+
+// RetrieveGPGKeys calls RetrieveGPGKey for each of the given PGP keys.
+func RetrieveGPGKeys(pgpKeys []string) ([]string, error) {
+	encryptionKeys := make([]string, 0, len(pgpKeys))
+
+	for _, pgpKey := range pgpKeys {
+		encryptionKey, err := RetrieveGPGKey(pgpKey)
+		if err != nil {
+			return nil, err
+		}
+
+		encryptionKeys = append(encryptionKeys, encryptionKey)
+	}
+
+	return encryptionKeys, nil
+}
+
+// EncryptValueForRecipients encrypts the given value once, such that it can be decrypted with any of the given
+// encryption keys, and returns the fingerprints of the keys. Description should be set such that errors return a
+// meaningful user-facing response.
+func EncryptValueForRecipients(encryptionKeys []string, value, description string) ([]string, string, error) {
+	entities, err := pgpkeys.GetEntities(encryptionKeys)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error encrypting %s: %w", description, err)
+	}
+
+	ctBuf := bytes.NewBuffer(nil)
+	pt, err := openpgp.Encrypt(ctBuf, entities, nil, nil, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error encrypting %s: error setting up encryption for PGP message: %w", description, err)
+	}
+
+	if _, err := pt.Write([]byte(value)); err != nil {
+		return nil, "", fmt.Errorf("Error encrypting %s: error encrypting PGP message: %w", description, err)
+	}
+
+	if err := pt.Close(); err != nil {
+		return nil, "", fmt.Errorf("Error encrypting %s: error encrypting PGP message: %w", description, err)
+	}
+
+	fingerprints, err := pgpkeys.GetFingerprints(nil, entities)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error encrypting %s: %w", description, err)
+	}
+
+	return fingerprints, base64.StdEncoding.EncodeToString(ctBuf.Bytes()), nil
+}
@@ -0,0 +1,51 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"filippo.io/age"
+)
+
+// ParseAgeRecipient parses an age X25519 recipient ("age1...").
+func ParseAgeRecipient(recipient string) (*age.X25519Recipient, error) {
+	r, err := age.ParseX25519Recipient(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("malformed age recipient (%s): %w", recipient, err)
+	}
+
+	return r, nil
+}
+
+// EncryptAgeValue encrypts the given value to the given age recipients. Any of the recipients can decrypt the
+// result, which is base64 encoded. Description should be set such that errors return a meaningful user-facing response.
+func EncryptAgeValue(recipients []string, value, description string) (string, error) {
+	ageRecipients := make([]age.Recipient, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		r, err := ParseAgeRecipient(recipient)
+		if err != nil {
+			return "", err
+		}
+
+		ageRecipients = append(ageRecipients, r)
+	}
+
+	buf := &bytes.Buffer{}
+
+	w, err := age.Encrypt(buf, ageRecipients...)
+	if err != nil {
+		return "", fmt.Errorf("Error encrypting %s: %w", description, err)
+	}
+
+	if _, err := w.Write([]byte(value)); err != nil {
+		return "", fmt.Errorf("Error encrypting %s: %w", description, err)
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("Error encrypting %s: %w", description, err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
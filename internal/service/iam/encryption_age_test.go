@@ -0,0 +1,94 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+)
+
+// The example key pair from the age package examples.
+const (
+	testAgeIdentity  = "AGE-SECRET-KEY-184JMZMVQH3E6U0PSL869004Y3U2NYV7R30EU99CSEDNPH02YUVFSZW44VU"
+	testAgeRecipient = "age1cy0su9fwf3gf9mw868g5yut09p6nytfmmnktexz2ya5uqg9vl9sss4euqm"
+)
+
+func TestParseAgeRecipient(t *testing.T) {
+	recipient, err := ParseAgeRecipient(testAgeRecipient)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	identity, err := age.ParseX25519Identity(testAgeIdentity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, expected := recipient.String(), identity.Recipient().String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	for _, recipient := range []string{
+		"",
+		"age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8q",
+		"Age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+		"age1qyqszqgpqyqszqgpqyqszqgpqyqszqgp5lfeyk",
+		testAgeIdentity,
+	} {
+		if _, err := ParseAgeRecipient(recipient); err == nil {
+			t.Errorf("expected an error for %q", recipient)
+		}
+	}
+}
+
+func TestEncryptAgeValue(t *testing.T) {
+	identity, err := age.ParseX25519Identity(testAgeIdentity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{"", "secret", string(bytes.Repeat([]byte("s"), 64*1024+1))} {
+		encrypted, err := EncryptAgeValue([]string{testAgeRecipient, other.Recipient().String()}, value, "test value")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		for _, identity := range []age.Identity{identity, other} {
+			if got := testAgeDecrypt(t, identity, encrypted); got != value {
+				t.Errorf("decrypted value of length %d does not match the value of length %d", len(got), len(value))
+			}
+		}
+	}
+
+	if _, err := EncryptAgeValue([]string{"age1invalid"}, "secret", "test value"); err == nil {
+		t.Error("expected an error for an invalid recipient")
+	}
+}
+
+func testAgeDecrypt(t *testing.T, identity age.Identity, encrypted string) string {
+	t.Helper()
+
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("decoding: %s", err)
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		t.Fatalf("decrypting: %s", err)
+	}
+
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("decrypting: %s", err)
+	}
+
+	return string(plaintext)
+}
@@ -0,0 +1,119 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The expiring credential engine is shared by the resources of credentials that expire after max_age seconds.
+// The expiration date is computed from the creation date of the credential, and the credential is replaced,
+// or rotated in place for access keys, once it has expired.
+
+// expiringCredentialDefaultMaxAge is the default max_age, with which the credential practically never expires.
+const expiringCredentialDefaultMaxAge = math.MaxInt32
+
+// expiringCredentialSchema adds the max_age, create_date and expiration_date attributes to the given schema.
+func expiringCredentialSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["create_date"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["expiration_date"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["max_age"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		ForceNew: true,
+		Optional: true,
+		Default:  expiringCredentialDefaultMaxAge,
+	}
+
+	return s
+}
+
+// resourceExpiringCredentialDiff forces the replacement of the credential once it has expired.
+func resourceExpiringCredentialDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	expired, err := expiringCredentialExpired(d.Get("expiration_date").(string))
+	if err != nil {
+		return err
+	}
+
+	if expired {
+		d.SetNewComputed("expiration_date")
+		return d.ForceNew("expiration_date")
+	}
+	return nil
+}
+
+// expiringCredentialExpired returns whether the expiration date, in RFC 3339 format, has passed.
+// An empty expiration date never expires.
+func expiringCredentialExpired(expirationDate string) (bool, error) {
+	if expirationDate == "" {
+		return false, nil
+	}
+
+	expirationTimestamp, err := time.Parse(time.RFC3339, expirationDate)
+	if err != nil {
+		return false, fmt.Errorf("error parsing expiration_date (%s): %s", expirationDate, err)
+	}
+
+	return time.Now().UTC().After(expirationTimestamp), nil
+}
+
+// setExpiringCredentialDates sets create_date, and expiration_date max_age seconds later.
+func setExpiringCredentialDates(d *schema.ResourceData, createDate *time.Time) {
+	if createDate == nil {
+		d.Set("create_date", nil)
+		d.Set("expiration_date", nil)
+		return
+	}
+
+	expirationDate := createDate.Add(time.Duration(d.Get("max_age").(int)) * time.Second)
+
+	d.Set("create_date", aws.TimeValue(createDate).Format(time.RFC3339))
+	d.Set("expiration_date", expirationDate.Format(time.RFC3339))
+}
+
+// expiringCredentialParseImportID splits an import ID of the form ID/MAX-AGE-SECONDS. When the import ID has no max
+// age, the default max_age is returned, since the default of the schema does not apply on import.
+func expiringCredentialParseImportID(importID string) (string, int, error) {
+	i := strings.LastIndex(importID, "/")
+	if i == -1 {
+		return importID, expiringCredentialDefaultMaxAge, nil
+	}
+
+	id, v := importID[:i], importID[i+1:]
+
+	maxAge, err := strconv.Atoi(v)
+	if id == "" || err != nil || maxAge <= 0 {
+		return "", 0, fmt.Errorf("unexpected format for ID (%s), expected ID or ID/MAX-AGE-SECONDS", importID)
+	}
+
+	return id, maxAge, nil
+}
+
+// expiringCredentialImport strips the optional /MAX-AGE-SECONDS suffix from the import ID and sets max_age, so that
+// an imported credential does not expire, and get replaced, right away.
+func expiringCredentialImport(d *schema.ResourceData) error {
+	id, maxAge, err := expiringCredentialParseImportID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	d.Set("max_age", maxAge)
+
+	return nil
+}
@@ -0,0 +1,363 @@
+package iam
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	loginProfileDefaultPasswordLength = 20
+
+	// loginProfileMaxPasswordLength is the longest password IAM accepts.
+	loginProfileMaxPasswordLength = 128
+
+	// loginProfileDeleteTimeout is how long the deletion is retried while IAM reports that the login profile
+	// cannot be modified yet, which happens right after it is created.
+	loginProfileDeleteTimeout = 1 * time.Minute
+
+	passwordCharsLower   = "abcdefghijklmnopqrstuvwxyz"
+	passwordCharsUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordCharsNumbers = "0123456789"
+	passwordCharsSymbols = "!@#$%^&*()_+-=[]{}|'"
+)
+
+func ResourceExpiringLoginProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides an IAM user login profile with a generated console password that expires after max_age seconds. The
+password meets the account password policy, and a new password is set, in place, once it expires. Use ` + "`pgp_key`" + ` to
+store the password encrypted instead of in plaintext.`,
+		Create: resourceLoginProfileCreate,
+		Read:   resourceLoginProfileRead,
+		Update: resourceLoginProfileUpdate,
+		Delete: resourceLoginProfileDelete,
+
+		CustomizeDiff: resourceLoginProfileDiff,
+
+		Importer: &schema.ResourceImporter{
+			// The import ID may end with /MAX-AGE-SECONDS, so that the expiration date of an existing login profile is
+			// computed from its creation date.
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := expiringCredentialImport(d); err != nil {
+					return nil, err
+				}
+
+				// The password cannot be read back, so only set what would otherwise show as a change.
+				d.Set("password_length", loginProfileDefaultPasswordLength)
+				d.Set("password_reset_required", false)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: expiringCredentialSchema(map[string]*schema.Schema{
+			"encrypted_password": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_fingerprint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"password_length": {
+				Description:  "The length of the generated password. The minimum length of the account password policy takes precedence when it is longer.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      loginProfileDefaultPasswordLength,
+				ValidateFunc: validation.IntBetween(8, loginProfileMaxPasswordLength),
+			},
+			"password_reset_required": {
+				Description: "Whether the user must set a new password at their next sign-in.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"pgp_key": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func resourceLoginProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+	user := d.Get("user").(string)
+
+	policy, err := findAccountPasswordPolicy(conn)
+	if err != nil {
+		return err
+	}
+
+	password, err := generatePassword(d.Get("password_length").(int), policy)
+	if err != nil {
+		return fmt.Errorf("error generating password for IAM User Login Profile (%s): %w", user, err)
+	}
+
+	output, err := conn.CreateLoginProfile(&iam.CreateLoginProfileInput{
+		Password:              aws.String(password),
+		PasswordResetRequired: aws.Bool(d.Get("password_reset_required").(bool)),
+		UserName:              aws.String(user),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating IAM User Login Profile (%s): %w", user, err)
+	}
+
+	d.SetId(aws.StringValue(output.LoginProfile.UserName))
+
+	if err := setLoginProfilePassword(d, password); err != nil {
+		return err
+	}
+
+	setExpiringCredentialDates(d, output.LoginProfile.CreateDate)
+
+	return resourceLoginProfileReadResult(d, output.LoginProfile)
+}
+
+func resourceLoginProfileRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	output, err := conn.GetLoginProfile(&iam.GetLoginProfileInput{
+		UserName: aws.String(d.Id()),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM User Login Profile (%s): %w", d.Id(), err)
+	}
+
+	return resourceLoginProfileReadResult(d, output.LoginProfile)
+}
+
+func resourceLoginProfileReadResult(d *schema.ResourceData, loginProfile *iam.LoginProfile) error {
+	// The creation date does not change when the password is rotated, so the expiration date is only computed
+	// from it when it is not known yet, after an import.
+	if d.Get("expiration_date").(string) == "" {
+		setExpiringCredentialDates(d, loginProfile.CreateDate)
+	} else if loginProfile.CreateDate != nil {
+		d.Set("create_date", aws.TimeValue(loginProfile.CreateDate).Format(time.RFC3339))
+	}
+
+	d.Set("user", loginProfile.UserName)
+
+	// The flag is cleared once the user has set a new password, which is not a change of the configuration.
+	if d.IsNewResource() || aws.BoolValue(loginProfile.PasswordResetRequired) {
+		d.Set("password_reset_required", loginProfile.PasswordResetRequired)
+	}
+
+	return nil
+}
+
+// resourceLoginProfileDiff plans the rotation of the password, in place, once it has expired.
+func resourceLoginProfileDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	expired, err := expiringCredentialExpired(d.Get("expiration_date").(string))
+	if err != nil {
+		return err
+	}
+
+	if !expired {
+		return nil
+	}
+
+	for _, k := range []string{"encrypted_password", "expiration_date", "key_fingerprint", "password"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceLoginProfileUpdate sets a new password once the current one has expired.
+func resourceLoginProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	o, _ := d.GetChange("expiration_date")
+	expirationDate := o.(string)
+
+	expired, err := expiringCredentialExpired(expirationDate)
+	if err != nil {
+		return err
+	}
+
+	if !expired {
+		d.Set("expiration_date", expirationDate)
+		return resourceLoginProfileRead(d, meta)
+	}
+
+	policy, err := findAccountPasswordPolicy(conn)
+	if err != nil {
+		return err
+	}
+
+	password, err := generatePassword(d.Get("password_length").(int), policy)
+	if err != nil {
+		return fmt.Errorf("error generating password for IAM User Login Profile (%s): %w", d.Id(), err)
+	}
+
+	_, err = conn.UpdateLoginProfile(&iam.UpdateLoginProfileInput{
+		Password:              aws.String(password),
+		PasswordResetRequired: aws.Bool(d.Get("password_reset_required").(bool)),
+		UserName:              aws.String(d.Id()),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error rotating IAM User Login Profile (%s) password: %w", d.Id(), err)
+	}
+
+	if err := setLoginProfilePassword(d, password); err != nil {
+		return err
+	}
+
+	setExpiringCredentialDates(d, aws.Time(time.Now().UTC()))
+
+	return resourceLoginProfileRead(d, meta)
+}
+
+// setLoginProfilePassword sets the password, encrypted with `pgp_key` when it is set.
+func setLoginProfilePassword(d *schema.ResourceData, password string) error {
+	v, ok := d.GetOk("pgp_key")
+	if !ok {
+		d.Set("password", password)
+		return nil
+	}
+
+	encryptionKey, err := RetrieveGPGKey(v.(string))
+	if err != nil {
+		return err
+	}
+
+	fingerprint, encrypted, err := EncryptValue(encryptionKey, password, "IAM User Login Profile password")
+	if err != nil {
+		return err
+	}
+
+	d.Set("key_fingerprint", fingerprint)
+	d.Set("encrypted_password", encrypted)
+
+	return nil
+}
+
+func resourceLoginProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	_, err := tfresource.RetryWhenAWSErrCodeEquals(loginProfileDeleteTimeout, func() (interface{}, error) {
+		return conn.DeleteLoginProfile(&iam.DeleteLoginProfileInput{
+			UserName: aws.String(d.Id()),
+		})
+	}, iam.ErrCodeEntityTemporarilyUnmodifiableException)
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting IAM User Login Profile (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+// findAccountPasswordPolicy returns the account password policy, or nil when the account uses the default policy.
+func findAccountPasswordPolicy(conn *iam.IAM) (*iam.PasswordPolicy, error) {
+	output, err := conn.GetAccountPasswordPolicy(&iam.GetAccountPasswordPolicyInput{})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading IAM Account Password Policy: %w", err)
+	}
+
+	return output.PasswordPolicy, nil
+}
+
+// generatePassword returns a random password of at least the given length, and of at least the minimum length of
+// the password policy. The password always contains lowercase and uppercase letters, numbers and symbols, which
+// satisfies any password policy as well as the default policy.
+func generatePassword(length int, policy *iam.PasswordPolicy) (string, error) {
+	if policy != nil && int(aws.Int64Value(policy.MinimumPasswordLength)) > length {
+		length = int(aws.Int64Value(policy.MinimumPasswordLength))
+	}
+
+	classes := []string{passwordCharsLower, passwordCharsUpper, passwordCharsNumbers, passwordCharsSymbols}
+	all := passwordCharsLower + passwordCharsUpper + passwordCharsNumbers + passwordCharsSymbols
+
+	if length < len(classes) {
+		length = len(classes)
+	}
+
+	password := make([]byte, 0, length)
+
+	for _, chars := range classes {
+		c, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, c)
+	}
+
+	for len(password) < length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, c)
+	}
+
+	// Shuffle so that the characters of each class are not always in the same position.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[n.Int64()], nil
+}
@@ -0,0 +1,83 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Length         int
+		Policy         *iam.PasswordPolicy
+		ExpectedLength int
+	}{
+		{
+			Name:           "default policy",
+			Length:         20,
+			ExpectedLength: 20,
+		},
+		{
+			Name:           "shorter policy",
+			Length:         20,
+			Policy:         &iam.PasswordPolicy{MinimumPasswordLength: aws.Int64(14)},
+			ExpectedLength: 20,
+		},
+		{
+			Name:           "longer policy",
+			Length:         20,
+			Policy:         &iam.PasswordPolicy{MinimumPasswordLength: aws.Int64(32)},
+			ExpectedLength: 32,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			password, err := generatePassword(testCase.Length, testCase.Policy)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(password) != testCase.ExpectedLength {
+				t.Errorf("expected length %d, got %d", testCase.ExpectedLength, len(password))
+			}
+
+			for _, chars := range []string{passwordCharsLower, passwordCharsUpper, passwordCharsNumbers, passwordCharsSymbols} {
+				if !strings.ContainsAny(password, chars) {
+					t.Errorf("expected password to contain any of %q", chars)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginProfileRotationPlan(t *testing.T) {
+	r := ResourceExpiringLoginProfile()
+	d := r.Data(&terraform.InstanceState{ID: "test"})
+
+	d.Set("max_age", 3600)
+	d.Set("password", "secret")
+	d.Set("password_length", loginProfileDefaultPasswordLength)
+	d.Set("password_reset_required", false)
+	d.Set("user", "test")
+	setExpiringCredentialDates(d, aws.Time(time.Now().UTC().Add(-2*time.Hour)))
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{"max_age": 3600, "user": "test"}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff.Empty() || !diff.Attributes["expiration_date"].NewComputed || !diff.Attributes["password"].NewComputed {
+		t.Fatalf("expected the password to be rotated, got %#v", diff)
+	}
+
+	if diff.RequiresNew() {
+		t.Errorf("expected the password to be rotated in place, got %#v", diff.Attributes)
+	}
+}
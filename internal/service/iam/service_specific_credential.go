@@ -0,0 +1,220 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceExpiringServiceSpecificCredential() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides an IAM service-specific credential, such as HTTPS Git credentials for CodeCommit, that expires after max_age
+seconds. The credential is replaced once it expires.`,
+		Create: resourceServiceSpecificCredentialCreate,
+		Read:   resourceServiceSpecificCredentialRead,
+		Update: resourceServiceSpecificCredentialUpdate,
+		Delete: resourceServiceSpecificCredentialDelete,
+
+		CustomizeDiff: resourceExpiringCredentialDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceServiceSpecificCredentialImport,
+		},
+
+		Schema: expiringCredentialSchema(map[string]*schema.Schema{
+			"service_name": {
+				Description: "The name of the AWS service the credential is for, e.g. `codecommit.amazonaws.com`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"service_password": {
+				Description: "The generated password for the service-specific credential.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"service_specific_credential_id": {
+				Description: "The unique identifier for the service-specific credential.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"service_user_name": {
+				Description: "The generated user name for the service-specific credential.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      iam.StatusTypeActive,
+				ValidateFunc: validation.StringInSlice(iam.StatusType_Values(), false),
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func resourceServiceSpecificCredentialCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	input := &iam.CreateServiceSpecificCredentialInput{
+		ServiceName: aws.String(d.Get("service_name").(string)),
+		UserName:    aws.String(d.Get("user").(string)),
+	}
+
+	output, err := conn.CreateServiceSpecificCredential(input)
+	if err != nil {
+		return fmt.Errorf("error creating IAM Service Specific Credential for user %s: %w", d.Get("user").(string), err)
+	}
+
+	credential := output.ServiceSpecificCredential
+
+	d.SetId(serviceSpecificCredentialCreateResourceID(aws.StringValue(credential.ServiceName), aws.StringValue(credential.UserName), aws.StringValue(credential.ServiceSpecificCredentialId)))
+	d.Set("service_password", credential.ServicePassword)
+
+	if v := d.Get("status").(string); v == iam.StatusTypeInactive {
+		if err := updateServiceSpecificCredentialStatus(conn, aws.StringValue(credential.ServiceSpecificCredentialId), aws.StringValue(credential.UserName), v); err != nil {
+			return err
+		}
+
+		credential.Status = aws.String(v)
+	}
+
+	return resourceServiceSpecificCredentialReadResult(d, &iam.ServiceSpecificCredentialMetadata{
+		CreateDate:                  credential.CreateDate,
+		ServiceName:                 credential.ServiceName,
+		ServiceSpecificCredentialId: credential.ServiceSpecificCredentialId,
+		ServiceUserName:             credential.ServiceUserName,
+		Status:                      credential.Status,
+		UserName:                    credential.UserName,
+	})
+}
+
+func resourceServiceSpecificCredentialRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	serviceName, userName, credentialID, err := serviceSpecificCredentialParseResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	output, err := conn.ListServiceSpecificCredentials(&iam.ListServiceSpecificCredentialsInput{
+		ServiceName: aws.String(serviceName),
+		UserName:    aws.String(userName),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		// the user does not exist, so the credential can't exist.
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM Service Specific Credential (%s): %w", d.Id(), err)
+	}
+
+	for _, credential := range output.ServiceSpecificCredentials {
+		if aws.StringValue(credential.ServiceSpecificCredentialId) == credentialID {
+			return resourceServiceSpecificCredentialReadResult(d, credential)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceServiceSpecificCredentialReadResult(d *schema.ResourceData, credential *iam.ServiceSpecificCredentialMetadata) error {
+	setExpiringCredentialDates(d, credential.CreateDate)
+
+	d.Set("service_name", credential.ServiceName)
+	d.Set("service_specific_credential_id", credential.ServiceSpecificCredentialId)
+	d.Set("service_user_name", credential.ServiceUserName)
+	d.Set("status", credential.Status)
+	d.Set("user", credential.UserName)
+
+	return nil
+}
+
+func resourceServiceSpecificCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	if d.HasChange("status") {
+		if err := updateServiceSpecificCredentialStatus(conn, d.Get("service_specific_credential_id").(string), d.Get("user").(string), d.Get("status").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceServiceSpecificCredentialRead(d, meta)
+}
+
+func resourceServiceSpecificCredentialDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	_, err := conn.DeleteServiceSpecificCredential(&iam.DeleteServiceSpecificCredentialInput{
+		ServiceSpecificCredentialId: aws.String(d.Get("service_specific_credential_id").(string)),
+		UserName:                    aws.String(d.Get("user").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting IAM Service Specific Credential (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceServiceSpecificCredentialImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := expiringCredentialImport(d); err != nil {
+		return nil, err
+	}
+
+	if _, _, _, err := serviceSpecificCredentialParseResourceID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateServiceSpecificCredentialStatus(conn *iam.IAM, id, user, status string) error {
+	_, err := conn.UpdateServiceSpecificCredential(&iam.UpdateServiceSpecificCredentialInput{
+		ServiceSpecificCredentialId: aws.String(id),
+		Status:                      aws.String(status),
+		UserName:                    aws.String(user),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating IAM Service Specific Credential (%s) status: %w", id, err)
+	}
+
+	return nil
+}
+
+const serviceSpecificCredentialResourceIDSeparator = ":"
+
+func serviceSpecificCredentialCreateResourceID(serviceName, userName, credentialID string) string {
+	return strings.Join([]string{serviceName, userName, credentialID}, serviceSpecificCredentialResourceIDSeparator)
+}
+
+func serviceSpecificCredentialParseResourceID(id string) (string, string, string, error) {
+	parts := strings.Split(id, serviceSpecificCredentialResourceIDSeparator)
+
+	if len(parts) == 3 && parts[0] != "" && parts[1] != "" && parts[2] != "" {
+		return parts[0], parts[1], parts[2], nil
+	}
+
+	return "", "", "", fmt.Errorf("unexpected format for ID (%[1]s), expected SERVICE-NAME%[2]sUSER-NAME%[2]sSERVICE-SPECIFIC-CREDENTIAL-ID", id, serviceSpecificCredentialResourceIDSeparator)
+}
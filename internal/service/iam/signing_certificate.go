@@ -0,0 +1,260 @@
+package iam
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// signingCertificateRSABits is the size of the generated RSA keys. IAM only accepts RSA keys of 1024 or 2048 bits for
+// signing certificates.
+const signingCertificateRSABits = 2048
+
+func ResourceExpiringSigningCertificate() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides an IAM user X.509 signing certificate that expires after max_age seconds. The certificate is replaced
+once it expires. When ` + "`certificate_body`" + ` is not set, a new RSA key and self-signed certificate, valid until the
+expiration date, are generated each time the certificate is replaced, and the private key is available as ` + "`private_key_pem`" + `.`,
+		Create: resourceSigningCertificateCreate,
+		Read:   resourceSigningCertificateRead,
+		Update: resourceSigningCertificateUpdate,
+		Delete: resourceSigningCertificateDelete,
+
+		CustomizeDiff: resourceExpiringCredentialDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceSigningCertificateImport,
+		},
+
+		Schema: expiringCredentialSchema(map[string]*schema.Schema{
+			"certificate_body": {
+				Description: "The signing certificate to upload, in PEM format. When not set, a new RSA key and self-signed certificate are generated.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// IAM may return the certificate with different surrounding whitespace.
+					return new != "" && strings.TrimSpace(old) == strings.TrimSpace(new)
+				},
+			},
+			"certificate_id": {
+				Description: "The ID of the signing certificate.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"private_key_pem": {
+				Description: "The generated private key, in PEM (PKCS#1) format. Only set when `certificate_body` is not set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      iam.StatusTypeActive,
+				ValidateFunc: validation.StringInSlice(iam.StatusType_Values(), false),
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func resourceSigningCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	user := d.Get("user").(string)
+	certificateBody := d.Get("certificate_body").(string)
+
+	if certificateBody == "" {
+		notAfter := time.Now().UTC().Add(time.Duration(d.Get("max_age").(int)) * time.Second)
+
+		privateKeyPEM, generatedCertificateBody, err := generateSigningCertificate(user, notAfter)
+		if err != nil {
+			return err
+		}
+
+		certificateBody = generatedCertificateBody
+		d.Set("private_key_pem", privateKeyPEM)
+	}
+
+	output, err := conn.UploadSigningCertificate(&iam.UploadSigningCertificateInput{
+		CertificateBody: aws.String(certificateBody),
+		UserName:        aws.String(user),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error uploading IAM Signing Certificate for user %s: %w", user, err)
+	}
+
+	certificate := output.Certificate
+	d.SetId(aws.StringValue(certificate.CertificateId))
+
+	if v := d.Get("status").(string); v == iam.StatusTypeInactive {
+		if err := updateSigningCertificateStatus(conn, d.Id(), aws.StringValue(certificate.UserName), v); err != nil {
+			return err
+		}
+
+		certificate.Status = aws.String(v)
+	}
+
+	return resourceSigningCertificateReadResult(d, certificate)
+}
+
+func resourceSigningCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	output, err := conn.ListSigningCertificates(&iam.ListSigningCertificatesInput{
+		UserName: aws.String(d.Get("user").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		// the user does not exist, so the certificate can't exist.
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM Signing Certificate (%s): %w", d.Id(), err)
+	}
+
+	for _, certificate := range output.Certificates {
+		if aws.StringValue(certificate.CertificateId) == d.Id() {
+			return resourceSigningCertificateReadResult(d, certificate)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSigningCertificateReadResult(d *schema.ResourceData, certificate *iam.SigningCertificate) error {
+	setExpiringCredentialDates(d, certificate.UploadDate)
+
+	d.Set("certificate_body", certificate.CertificateBody)
+	d.Set("certificate_id", certificate.CertificateId)
+	d.Set("status", certificate.Status)
+	d.Set("user", certificate.UserName)
+
+	return nil
+}
+
+func resourceSigningCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	if d.HasChange("status") {
+		if err := updateSigningCertificateStatus(conn, d.Id(), d.Get("user").(string), d.Get("status").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceSigningCertificateRead(d, meta)
+}
+
+func resourceSigningCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	_, err := conn.DeleteSigningCertificate(&iam.DeleteSigningCertificateInput{
+		CertificateId: aws.String(d.Id()),
+		UserName:      aws.String(d.Get("user").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting IAM Signing Certificate (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceSigningCertificateImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := expiringCredentialImport(d); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(d.Id(), ":")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format for ID (%s), expected USER-NAME:CERTIFICATE-ID or USER-NAME:CERTIFICATE-ID/MAX-AGE-SECONDS", d.Id())
+	}
+
+	d.Set("user", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateSigningCertificateStatus(conn *iam.IAM, id, user, status string) error {
+	_, err := conn.UpdateSigningCertificate(&iam.UpdateSigningCertificateInput{
+		CertificateId: aws.String(id),
+		Status:        aws.String(status),
+		UserName:      aws.String(user),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating IAM Signing Certificate (%s) status: %w", id, err)
+	}
+
+	return nil
+}
+
+// generateSigningCertificate returns a new RSA private key in PEM format, and a self-signed certificate for the user
+// in PEM format that is valid until notAfter.
+func generateSigningCertificate(user string, notAfter time.Time) (string, string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, signingCertificateRSABits)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating RSA key: %w", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("error generating certificate serial number: %w", err)
+	}
+
+	notBefore := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: user},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating signing certificate: %w", err)
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certificate,
+	})
+
+	return string(privateKeyPEM), string(certificatePEM), nil
+}
@@ -0,0 +1,53 @@
+package iam
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+func TestGenerateSigningCertificate(t *testing.T) {
+	notAfter := time.Now().UTC().Add(90 * 24 * time.Hour).Truncate(time.Second)
+
+	privateKeyPEM, certificatePEM, err := generateSigningCertificate("test", notAfter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		t.Fatal("expected a PEM encoded private key")
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("error parsing private key: %s", err)
+	}
+
+	block, _ = pem.Decode([]byte(certificatePEM))
+	if block == nil {
+		t.Fatal("expected a PEM encoded certificate")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("error parsing certificate: %s", err)
+	}
+
+	if got := certificate.Subject.CommonName; got != "test" {
+		t.Errorf("expected common name test, got %s", got)
+	}
+
+	if !certificate.NotAfter.Equal(notAfter) {
+		t.Errorf("expected certificate to expire at %s, got %s", notAfter, certificate.NotAfter)
+	}
+
+	if !privateKey.PublicKey.Equal(certificate.PublicKey) {
+		t.Error("expected the certificate to be issued for the generated key")
+	}
+
+	if err := certificate.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature); err != nil {
+		t.Errorf("expected a self-signed certificate: %s", err)
+	}
+}
@@ -0,0 +1,244 @@
+package iam
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyRSABits is the size of the generated RSA keys. IAM only accepts RSA keys, of at least 2048 bits.
+const sshKeyRSABits = 4096
+
+func ResourceExpiringSSHKey() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides an IAM user SSH public key, as used for CodeCommit, that expires after max_age seconds. The key is replaced
+once it expires. When ` + "`public_key`" + ` is not set, a new RSA key pair is generated each time the key is replaced, and
+the private key is available as ` + "`private_key_pem`" + `.`,
+		Create: resourceSSHKeyCreate,
+		Read:   resourceSSHKeyRead,
+		Update: resourceSSHKeyUpdate,
+		Delete: resourceSSHKeyDelete,
+
+		CustomizeDiff: resourceExpiringCredentialDiff,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceSSHKeyImport,
+		},
+
+		Schema: expiringCredentialSchema(map[string]*schema.Schema{
+			"fingerprint": {
+				Description: "The MD5 message digest of the SSH public key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"private_key_pem": {
+				Description: "The generated private key, in PEM (PKCS#1) format. Only set when `public_key` is not set.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"public_key": {
+				Description: "The SSH public key to upload, in OpenSSH format. When not set, a new RSA key pair is generated.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// IAM returns the key without its comment.
+					return new != "" && sshKeyWithoutComment(old) == sshKeyWithoutComment(new)
+				},
+			},
+			"ssh_public_key_id": {
+				Description: "The unique identifier for the SSH public key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      iam.StatusTypeActive,
+				ValidateFunc: validation.StringInSlice(iam.StatusType_Values(), false),
+			},
+			"user": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func resourceSSHKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	publicKey := d.Get("public_key").(string)
+
+	if publicKey == "" {
+		privateKeyPEM, generatedPublicKey, err := generateSSHKey()
+		if err != nil {
+			return err
+		}
+
+		publicKey = generatedPublicKey
+		d.Set("private_key_pem", privateKeyPEM)
+	}
+
+	output, err := conn.UploadSSHPublicKey(&iam.UploadSSHPublicKeyInput{
+		SSHPublicKeyBody: aws.String(publicKey),
+		UserName:         aws.String(d.Get("user").(string)),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error uploading IAM SSH Public Key for user %s: %w", d.Get("user").(string), err)
+	}
+
+	key := output.SSHPublicKey
+	d.SetId(aws.StringValue(key.SSHPublicKeyId))
+
+	if v := d.Get("status").(string); v == iam.StatusTypeInactive {
+		if err := updateSSHKeyStatus(conn, d.Id(), aws.StringValue(key.UserName), v); err != nil {
+			return err
+		}
+
+		key.Status = aws.String(v)
+	}
+
+	return resourceSSHKeyReadResult(d, key)
+}
+
+func resourceSSHKeyRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	output, err := conn.GetSSHPublicKey(&iam.GetSSHPublicKeyInput{
+		Encoding:       aws.String(iam.EncodingTypeSsh),
+		SSHPublicKeyId: aws.String(d.Id()),
+		UserName:       aws.String(d.Get("user").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading IAM SSH Public Key (%s): %w", d.Id(), err)
+	}
+
+	return resourceSSHKeyReadResult(d, output.SSHPublicKey)
+}
+
+func resourceSSHKeyReadResult(d *schema.ResourceData, key *iam.SSHPublicKey) error {
+	setExpiringCredentialDates(d, key.UploadDate)
+
+	d.Set("fingerprint", key.Fingerprint)
+	d.Set("public_key", key.SSHPublicKeyBody)
+	d.Set("ssh_public_key_id", key.SSHPublicKeyId)
+	d.Set("status", key.Status)
+	d.Set("user", key.UserName)
+
+	return nil
+}
+
+func resourceSSHKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	if d.HasChange("status") {
+		if err := updateSSHKeyStatus(conn, d.Id(), d.Get("user").(string), d.Get("status").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceSSHKeyRead(d, meta)
+}
+
+func resourceSSHKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).IAMConn
+
+	_, err := conn.DeleteSSHPublicKey(&iam.DeleteSSHPublicKeyInput{
+		SSHPublicKeyId: aws.String(d.Id()),
+		UserName:       aws.String(d.Get("user").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, iam.ErrCodeNoSuchEntityException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting IAM SSH Public Key (%s): %w", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceSSHKeyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := expiringCredentialImport(d); err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(d.Id(), ":")
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format for ID (%s), expected USER-NAME:SSH-PUBLIC-KEY-ID or USER-NAME:SSH-PUBLIC-KEY-ID/MAX-AGE-SECONDS", d.Id())
+	}
+
+	d.Set("user", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateSSHKeyStatus(conn *iam.IAM, id, user, status string) error {
+	_, err := conn.UpdateSSHPublicKey(&iam.UpdateSSHPublicKeyInput{
+		SSHPublicKeyId: aws.String(id),
+		Status:         aws.String(status),
+		UserName:       aws.String(user),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating IAM SSH Public Key (%s) status: %w", id, err)
+	}
+
+	return nil
+}
+
+// generateSSHKey returns a new RSA private key in PEM format, and its public key in OpenSSH format.
+func generateSSHKey() (string, string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, sshKeyRSABits)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating RSA key: %w", err)
+	}
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("error generating SSH public key: %w", err)
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	return string(privateKeyPEM), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))), nil
+}
+
+// sshKeyWithoutComment returns the type and the base64-encoded key of an OpenSSH public key.
+func sshKeyWithoutComment(publicKey string) string {
+	fields := strings.Fields(publicKey)
+
+	if len(fields) < 2 {
+		return publicKey
+	}
+
+	return fields[0] + " " + fields[1]
+}
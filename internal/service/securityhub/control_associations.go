@@ -0,0 +1,230 @@
+package securityhub
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+)
+
+const (
+	// standardsControlAssociationsBatchSize is the number of standards control associations read or updated per request.
+	standardsControlAssociationsBatchSize = 100
+
+	// standardsControlAssociationsTimeout is how long throttled requests are retried.
+	standardsControlAssociationsTimeout = 5 * time.Minute
+
+	errCodeTooManyRequestsException = "TooManyRequestsException"
+)
+
+// errStandardsControlAssociationsThrottled is returned when some of the updates of a batch were not processed because
+// of the rate limits of Security Hub.
+var errStandardsControlAssociationsThrottled = errors.New("standards control association updates throttled")
+
+// securityControlIDsCache holds the IDs of the security controls of each standard, which only change with new
+// versions of the standards, for the lifetime of the provider. The entries are keyed by the Security Hub client of
+// the provider's AWSClient, so that provider configurations for other accounts or regions do not share them.
+var securityControlIDsCache sync.Map
+
+type securityControlIDsCacheKey struct {
+	conn         *securityhub.SecurityHub
+	standardsArn string
+}
+
+// findSecurityControlIDsByStandardCached returns the IDs of the security controls that are part of a standard, only
+// listing them once per standard and AWSClient.
+func findSecurityControlIDsByStandardCached(conn *securityhub.SecurityHub, standardsArn string) ([]string, error) {
+	key := securityControlIDsCacheKey{conn: conn, standardsArn: standardsArn}
+
+	if v, ok := securityControlIDsCache.Load(key); ok {
+		return v.([]string), nil
+	}
+
+	controlIDs, err := FindSecurityControlIDsByStandard(conn, standardsArn)
+	if err != nil {
+		return nil, err
+	}
+
+	securityControlIDsCache.Store(key, controlIDs)
+
+	return controlIDs, nil
+}
+
+// standardsControlAssociations holds the association of security controls with the enabled standards, by security
+// control ID and standards ARN.
+type standardsControlAssociations map[string]map[string]*securityhub.StandardsControlAssociationDetail
+
+// findStandardsControlAssociationsByControlIDs reads the associations of the given security controls with every
+// enabled standard, listing the controls of each standard once per AWSClient.
+func findStandardsControlAssociationsByControlIDs(conn *securityhub.SecurityHub, controlIDs []string) (standardsControlAssociations, error) {
+	wanted := stringSet(controlIDs)
+
+	standardsArns, err := FindEnabledStandardsArns(conn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading enabled security hub standards: %s", err)
+	}
+
+	var ids []*securityhub.StandardsControlAssociationId
+
+	for _, standardsArn := range standardsArns {
+		standardsControlIDs, err := findSecurityControlIDsByStandardCached(conn, standardsArn)
+		if err != nil {
+			return nil, fmt.Errorf("error reading security hub controls of standard %s: %s", standardsArn, err)
+		}
+
+		for _, controlID := range standardsControlIDs {
+			if wanted[controlID] {
+				ids = append(ids, &securityhub.StandardsControlAssociationId{
+					SecurityControlId: aws.String(controlID),
+					StandardsArn:      aws.String(standardsArn),
+				})
+			}
+		}
+	}
+
+	result := make(standardsControlAssociations, len(controlIDs))
+
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > standardsControlAssociationsBatchSize {
+			n = standardsControlAssociationsBatchSize
+		}
+
+		input := &securityhub.BatchGetStandardsControlAssociationsInput{
+			StandardsControlAssociationIds: ids[:n],
+		}
+		ids = ids[n:]
+
+		outputRaw, err := retryWhenSecurityHubThrottled(func() (interface{}, error) {
+			return conn.BatchGetStandardsControlAssociations(input)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading security hub standards control associations: %s", err)
+		}
+
+		output := outputRaw.(*securityhub.BatchGetStandardsControlAssociationsOutput)
+
+		if len(output.UnprocessedAssociations) > 0 {
+			var errs []string
+			for _, unprocessed := range output.UnprocessedAssociations {
+				errs = append(errs, fmt.Sprintf("%s in %s: %s (%s)",
+					aws.StringValue(unprocessed.StandardsControlAssociationId.SecurityControlId),
+					aws.StringValue(unprocessed.StandardsControlAssociationId.StandardsArn),
+					aws.StringValue(unprocessed.ErrorCode),
+					aws.StringValue(unprocessed.ErrorReason),
+				))
+			}
+
+			return nil, fmt.Errorf("error reading security hub standards control associations: %s", strings.Join(errs, ", "))
+		}
+
+		for _, detail := range output.StandardsControlAssociationDetails {
+			controlID := aws.StringValue(detail.SecurityControlId)
+
+			if result[controlID] == nil {
+				result[controlID] = make(map[string]*securityhub.StandardsControlAssociationDetail)
+			}
+
+			result[controlID][aws.StringValue(detail.StandardsArn)] = detail
+		}
+	}
+
+	return result, nil
+}
+
+// updates returns the updates that set the status of a security control in every enabled standard that includes it.
+// A reason is only sent when disabling the control.
+func (associations standardsControlAssociations) updates(controlID, status, reason string) []*securityhub.StandardsControlAssociationUpdate {
+	var result []*securityhub.StandardsControlAssociationUpdate
+
+	for standardsArn := range associations[controlID] {
+		update := &securityhub.StandardsControlAssociationUpdate{
+			AssociationStatus: aws.String(status),
+			SecurityControlId: aws.String(controlID),
+			StandardsArn:      aws.String(standardsArn),
+		}
+
+		if status == securityhub.AssociationStatusDisabled && reason != "" {
+			update.UpdatedReason = aws.String(reason)
+		}
+
+		result = append(result, update)
+	}
+
+	return result
+}
+
+// batchUpdateStandardsControlAssociations applies the updates in batches. Requests that are throttled, and updates
+// that are not processed because of rate limits, are retried.
+func batchUpdateStandardsControlAssociations(conn *securityhub.SecurityHub, updates []*securityhub.StandardsControlAssociationUpdate) error {
+	for len(updates) > 0 {
+		n := len(updates)
+		if n > standardsControlAssociationsBatchSize {
+			n = standardsControlAssociationsBatchSize
+		}
+
+		pending := updates[:n]
+		updates = updates[n:]
+
+		_, err := retryWhenSecurityHubThrottled(func() (interface{}, error) {
+			output, err := conn.BatchUpdateStandardsControlAssociations(&securityhub.BatchUpdateStandardsControlAssociationsInput{
+				StandardsControlAssociationUpdates: pending,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var throttled []*securityhub.StandardsControlAssociationUpdate
+			var errs []string
+
+			for _, unprocessed := range output.UnprocessedAssociationUpdates {
+				if aws.StringValue(unprocessed.ErrorCode) == securityhub.UnprocessedErrorCodeLimitExceeded {
+					throttled = append(throttled, unprocessed.StandardsControlAssociationUpdate)
+					continue
+				}
+
+				errs = append(errs, fmt.Sprintf("%s in %s: %s (%s)",
+					aws.StringValue(unprocessed.StandardsControlAssociationUpdate.SecurityControlId),
+					aws.StringValue(unprocessed.StandardsControlAssociationUpdate.StandardsArn),
+					aws.StringValue(unprocessed.ErrorCode),
+					aws.StringValue(unprocessed.ErrorReason),
+				))
+			}
+
+			if len(errs) > 0 {
+				return nil, errors.New(strings.Join(errs, ", "))
+			}
+
+			pending = throttled
+			if len(pending) > 0 {
+				return nil, errStandardsControlAssociationsThrottled
+			}
+
+			return output, nil
+		})
+
+		if err != nil {
+			return fmt.Errorf("error updating security hub standards control associations: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// retryWhenSecurityHubThrottled retries f while Security Hub reports that its rate limits are exceeded.
+func retryWhenSecurityHubThrottled(f func() (interface{}, error)) (interface{}, error) {
+	return tfresource.RetryWhen(standardsControlAssociationsTimeout, f, func(err error) (bool, error) {
+		if errors.Is(err, errStandardsControlAssociationsThrottled) ||
+			tfawserr.ErrCodeEquals(err, errCodeTooManyRequestsException, securityhub.ErrCodeLimitExceededException) {
+			return true, err
+		}
+
+		return false, err
+	})
+}
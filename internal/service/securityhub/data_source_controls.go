@@ -0,0 +1,182 @@
+package securityhub
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceSecurityHubControls() *schema.Resource {
+	return &schema.Resource{
+		Description: `Lists the controls of the Security Hub standards enabled in the configured region, with their status.
+
+Use this data source to look up the ARN of a control, e.g. for ` + "`awsutils_security_hub_control_disablement`" + `, instead
+of building it by hand.`,
+		Read:          dataSourceSecurityHubControlsRead,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"arns": {
+				Description: "The ARNs of the controls that match the filters.",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"controls": {
+				Description: "The controls that match the filters, in the order of the enabled standards.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": {
+							Description: "The ARN of the control.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"control_id": {
+							Description: "The ID of the control in its standard, e.g. `1.1` for CIS or `EC2.2` for AWS Foundational Security Best Practices.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"disabled_reason": {
+							Description: "The reason the control is disabled, if any.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"severity": {
+							Description: "The severity of the findings of the control.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"standards_arn": {
+							Description: "The ARN of the standard the control belongs to.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "The status of the control, `ENABLED` or `DISABLED`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"title": {
+							Description: "The title of the control.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"severities": {
+				Description: "Only include controls with one of these severities. Valid values are `LOW`, `MEDIUM`, `HIGH` and `CRITICAL`.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(securityhub.SeverityRating_Values(), false),
+				},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"standards_arns": {
+				Description: "Only include controls of these standards, e.g. " +
+					"`arn:aws:securityhub:us-east-1::standards/aws-foundational-security-best-practices/v/1.0.0`.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"status": {
+				Description:  "Only include controls with this status. Valid values are `ENABLED` and `DISABLED`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(securityhub.ControlStatus_Values(), false),
+			},
+		},
+	}
+}
+
+func dataSourceSecurityHubControlsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	standardsArns := stringSet(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("standards_arns").(*schema.Set))))
+	severities := stringSet(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("severities").(*schema.Set))))
+	status := d.Get("status").(string)
+
+	var tfList []interface{}
+	var arns []string
+
+	subscriptions, err := FindEnabledStandards(conn)
+	if err != nil {
+		return fmt.Errorf("error reading security hub controls: %s", err)
+	}
+
+	// Only list the controls of the requested standards, as each standard has up to a few hundred controls.
+	if len(standardsArns) > 0 {
+		var filtered []*securityhub.StandardsSubscription
+
+		for _, subscription := range subscriptions {
+			if standardsArns[aws.StringValue(subscription.StandardsArn)] {
+				filtered = append(filtered, subscription)
+			}
+		}
+
+		subscriptions = filtered
+	}
+
+	err = forEachStandardsControl(conn, subscriptions, func(subscription *securityhub.StandardsSubscription, control *securityhub.StandardsControl) bool {
+		if len(severities) > 0 && !severities[aws.StringValue(control.SeverityRating)] {
+			return true
+		}
+
+		if status != "" && aws.StringValue(control.ControlStatus) != status {
+			return true
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"arn":             aws.StringValue(control.StandardsControlArn),
+			"control_id":      aws.StringValue(control.ControlId),
+			"disabled_reason": aws.StringValue(control.DisabledReason),
+			"severity":        aws.StringValue(control.SeverityRating),
+			"standards_arn":   aws.StringValue(subscription.StandardsArn),
+			"status":          aws.StringValue(control.ControlStatus),
+			"title":           aws.StringValue(control.Title),
+		})
+		arns = append(arns, aws.StringValue(control.StandardsControlArn))
+
+		return true
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading security hub controls: %s", err)
+	}
+
+	d.SetId(meta.(*conns.AWSClient).Region)
+
+	if err := d.Set("controls", tfList); err != nil {
+		return fmt.Errorf("error setting controls: %s", err)
+	}
+
+	if err := d.Set("arns", arns); err != nil {
+		return fmt.Errorf("error setting arns: %s", err)
+	}
+
+	return nil
+}
+
+func stringSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		result[v] = true
+	}
+
+	return result
+}
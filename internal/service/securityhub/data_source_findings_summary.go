@@ -0,0 +1,302 @@
+package securityhub
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const defaultTopFailingResourcesLimit = 10
+
+func DataSourceSecurityHubFindingsSummary() *schema.Resource {
+	return &schema.Resource{
+		Description: `Summarizes the Security Hub findings of the configured region, with counts by severity, compliance status, control,
+account and region, and the resources with the most failed findings.
+
+Only active findings are counted unless ` + "`record_states`" + ` is set.`,
+		Read:          dataSourceSecurityHubFindingsSummaryRead,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"aws_account_ids": {
+				Description: "Only count findings of these accounts.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"by_account": {
+				Description: "The number of findings by account ID.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+			},
+			"by_compliance_status": {
+				Description: "The number of findings by compliance status. Findings without a compliance status are not counted.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+			},
+			"by_control_id": {
+				Description: "The number of findings by security control ID, e.g. `EC2.2`. Findings that are not about a control are not counted.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+			},
+			"by_region": {
+				Description: "The number of findings by region.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+			},
+			"by_severity": {
+				Description: "The number of findings by severity label.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Computed:    true,
+			},
+			"compliance_statuses": {
+				Description: "Only count findings with one of these compliance statuses. Valid values are `PASSED`, " +
+					"`WARNING`, `FAILED` and `NOT_AVAILABLE`.",
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(securityhub.ComplianceStatus_Values(), false),
+				},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"generator_ids": {
+				Description: "Only count findings generated by these solution-specific components, e.g. a control ID.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"product_names": {
+				Description: "Only count findings of these products, e.g. `Security Hub` or `GuardDuty`.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"record_states": {
+				Description: "Only count findings with one of these record states. Valid values are `ACTIVE` and `ARCHIVED`. " +
+					"Defaults to `ACTIVE`.",
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(securityhub.RecordState_Values(), false),
+				},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"regions": {
+				Description: "Only count findings of these regions, e.g. findings aggregated from them.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"severity_labels": {
+				Description: "Only count findings with one of these severity labels. Valid values are `INFORMATIONAL`, " +
+					"`LOW`, `MEDIUM`, `HIGH` and `CRITICAL`.",
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(securityhub.SeverityLabel_Values(), false),
+				},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+			"top_failing_resources": {
+				Description: "The resources with the most failed findings, by descending number of failed findings.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"failed_count": {
+							Description: "The number of failed findings of the resource.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"resource_id": {
+							Description: "The ID of the resource, e.g. its ARN.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"top_failing_resources_limit": {
+				Description:  "The maximum number of resources in `top_failing_resources`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultTopFailingResourcesLimit,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"total_count": {
+				Description: "The number of findings that match the filters.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"workflow_statuses": {
+				Description: "Only count findings with one of these workflow statuses. Valid values are `NEW`, " +
+					"`NOTIFIED`, `RESOLVED` and `SUPPRESSED`.",
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(securityhub.WorkflowStatus_Values(), false),
+				},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceSecurityHubFindingsSummaryRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	recordStates := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("record_states").(*schema.Set)))
+	if len(recordStates) == 0 {
+		recordStates = []string{securityhub.RecordStateActive}
+	}
+
+	filters := &securityhub.AwsSecurityFindingFilters{
+		AwsAccountId:     expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("aws_account_ids").(*schema.Set)))),
+		ComplianceStatus: expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("compliance_statuses").(*schema.Set)))),
+		GeneratorId:      expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("generator_ids").(*schema.Set)))),
+		ProductName:      expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("product_names").(*schema.Set)))),
+		RecordState:      expandStringFilters(recordStates),
+		Region:           expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("regions").(*schema.Set)))),
+		SeverityLabel:    expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("severity_labels").(*schema.Set)))),
+		WorkflowStatus:   expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("workflow_statuses").(*schema.Set)))),
+	}
+
+	summary := newFindingsSummary()
+
+	err := forEachFinding(conn, filters, func(finding *securityhub.AwsSecurityFinding) error {
+		summary.add(finding)
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("error reading security hub findings: %s", err)
+	}
+
+	d.SetId(meta.(*conns.AWSClient).Region)
+
+	d.Set("total_count", summary.total)
+	d.Set("by_account", summary.byAccount)
+	d.Set("by_compliance_status", summary.byComplianceStatus)
+	d.Set("by_control_id", summary.byControlID)
+	d.Set("by_region", summary.byRegion)
+	d.Set("by_severity", summary.bySeverity)
+
+	var tfList []interface{}
+	for _, resource := range summary.topFailingResources(d.Get("top_failing_resources_limit").(int)) {
+		tfList = append(tfList, map[string]interface{}{
+			"failed_count": resource.failedCount,
+			"resource_id":  resource.resourceID,
+		})
+	}
+
+	if err := d.Set("top_failing_resources", tfList); err != nil {
+		return fmt.Errorf("error setting top_failing_resources: %s", err)
+	}
+
+	return nil
+}
+
+// findingsSummary holds the counts of findings, which are added one at a time so that the findings themselves are not
+// kept in memory.
+type findingsSummary struct {
+	total              int
+	byAccount          map[string]int
+	byComplianceStatus map[string]int
+	byControlID        map[string]int
+	byRegion           map[string]int
+	bySeverity         map[string]int
+	failedByResource   map[string]int
+}
+
+type failingResource struct {
+	resourceID  string
+	failedCount int
+}
+
+func newFindingsSummary() *findingsSummary {
+	return &findingsSummary{
+		byAccount:          make(map[string]int),
+		byComplianceStatus: make(map[string]int),
+		byControlID:        make(map[string]int),
+		byRegion:           make(map[string]int),
+		bySeverity:         make(map[string]int),
+		failedByResource:   make(map[string]int),
+	}
+}
+
+func (s *findingsSummary) add(finding *securityhub.AwsSecurityFinding) {
+	s.total++
+	s.byAccount[aws.StringValue(finding.AwsAccountId)]++
+	s.byRegion[aws.StringValue(finding.Region)]++
+
+	if finding.Severity != nil && finding.Severity.Label != nil {
+		s.bySeverity[aws.StringValue(finding.Severity.Label)]++
+	}
+
+	if finding.Compliance == nil {
+		return
+	}
+
+	if controlID := aws.StringValue(finding.Compliance.SecurityControlId); controlID != "" {
+		s.byControlID[controlID]++
+	}
+
+	status := aws.StringValue(finding.Compliance.Status)
+	if status == "" {
+		return
+	}
+
+	s.byComplianceStatus[status]++
+
+	if status == securityhub.ComplianceStatusFailed {
+		for _, resource := range finding.Resources {
+			if resource != nil && resource.Id != nil {
+				s.failedByResource[aws.StringValue(resource.Id)]++
+			}
+		}
+	}
+}
+
+// topFailingResources returns at most limit resources, by descending number of failed findings and then by ID.
+func (s *findingsSummary) topFailingResources(limit int) []failingResource {
+	result := make([]failingResource, 0, len(s.failedByResource))
+	for resourceID, failedCount := range s.failedByResource {
+		result = append(result, failingResource{resourceID: resourceID, failedCount: failedCount})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].failedCount != result[j].failedCount {
+			return result[i].failedCount > result[j].failedCount
+		}
+		return result[i].resourceID < result[j].resourceID
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+package securityhub
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+)
+
+func testFinding(accountID, severity, controlID, status string, resourceIDs ...string) *securityhub.AwsSecurityFinding {
+	finding := &securityhub.AwsSecurityFinding{
+		AwsAccountId: aws.String(accountID),
+		Region:       aws.String("us-east-1"),
+		Severity:     &securityhub.Severity{Label: aws.String(severity)},
+	}
+
+	if controlID != "" || status != "" {
+		finding.Compliance = &securityhub.Compliance{}
+		if controlID != "" {
+			finding.Compliance.SecurityControlId = aws.String(controlID)
+		}
+		if status != "" {
+			finding.Compliance.Status = aws.String(status)
+		}
+	}
+
+	for _, resourceID := range resourceIDs {
+		finding.Resources = append(finding.Resources, &securityhub.Resource{Id: aws.String(resourceID)})
+	}
+
+	return finding
+}
+
+func TestFindingsSummary(t *testing.T) {
+	summary := newFindingsSummary()
+
+	for _, finding := range []*securityhub.AwsSecurityFinding{
+		testFinding("111111111111", "HIGH", "EC2.2", "FAILED", "sg-1"),
+		testFinding("111111111111", "HIGH", "EC2.2", "FAILED", "sg-2"),
+		testFinding("222222222222", "MEDIUM", "S3.8", "FAILED", "bucket-1", "sg-2"),
+		testFinding("222222222222", "LOW", "S3.8", "PASSED", "bucket-2"),
+		testFinding("222222222222", "CRITICAL", "", ""),
+	} {
+		summary.add(finding)
+	}
+
+	if summary.total != 5 {
+		t.Errorf("expected 5 findings, got %d", summary.total)
+	}
+
+	expectedByAccount := map[string]int{"111111111111": 2, "222222222222": 3}
+	if !reflect.DeepEqual(summary.byAccount, expectedByAccount) {
+		t.Errorf("expected %v by account, got %v", expectedByAccount, summary.byAccount)
+	}
+
+	expectedByComplianceStatus := map[string]int{"FAILED": 3, "PASSED": 1}
+	if !reflect.DeepEqual(summary.byComplianceStatus, expectedByComplianceStatus) {
+		t.Errorf("expected %v by compliance status, got %v", expectedByComplianceStatus, summary.byComplianceStatus)
+	}
+
+	expectedByControlID := map[string]int{"EC2.2": 2, "S3.8": 2}
+	if !reflect.DeepEqual(summary.byControlID, expectedByControlID) {
+		t.Errorf("expected %v by control ID, got %v", expectedByControlID, summary.byControlID)
+	}
+
+	expectedBySeverity := map[string]int{"CRITICAL": 1, "HIGH": 2, "LOW": 1, "MEDIUM": 1}
+	if !reflect.DeepEqual(summary.bySeverity, expectedBySeverity) {
+		t.Errorf("expected %v by severity, got %v", expectedBySeverity, summary.bySeverity)
+	}
+
+	expectedTop := []failingResource{
+		{resourceID: "sg-2", failedCount: 2},
+		{resourceID: "bucket-1", failedCount: 1},
+	}
+	if got := summary.topFailingResources(2); !reflect.DeepEqual(got, expectedTop) {
+		t.Errorf("expected top failing resources %v, got %v", expectedTop, got)
+	}
+
+	if got := summary.topFailingResources(0); len(got) != 0 {
+		t.Errorf("expected no top failing resources, got %v", got)
+	}
+}
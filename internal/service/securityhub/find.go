@@ -35,22 +35,59 @@ func FindAdminAccount(conn *securityhub.SecurityHub, adminAccountID string) (*se
 }
 
 func FindSecurityHubControl(conn *securityhub.SecurityHub, controlArn string) (*securityhub.StandardsControl, error) {
-	standardsInput := &securityhub.GetEnabledStandardsInput{}
-	standards, err := conn.GetEnabledStandards(standardsInput)
+	var foundControl *securityhub.StandardsControl
+
+	err := forEachSecurityHubControl(conn, func(_ *securityhub.StandardsSubscription, c *securityhub.StandardsControl) bool {
+		if aws.StringValue(c.StandardsControlArn) == controlArn {
+			foundControl = c
+			return false
+		}
+		return true
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	for _, s := range standards.StandardsSubscriptions {
+	if foundControl != nil {
+		return foundControl, nil
+	}
+
+	return nil, &resource.NotFoundError{
+		Message: fmt.Sprintf("Could not find a control with arn %s", controlArn),
+	}
+}
+
+// forEachSecurityHubControl calls f with each control of each enabled standard, until f returns false.
+func forEachSecurityHubControl(conn *securityhub.SecurityHub, f func(*securityhub.StandardsSubscription, *securityhub.StandardsControl) bool) error {
+	subscriptions, err := FindEnabledStandards(conn)
+	if err != nil {
+		return err
+	}
+
+	return forEachStandardsControl(conn, subscriptions, f)
+}
+
+// forEachStandardsControl calls f with each control of the given standards subscriptions, until f returns false.
+func forEachStandardsControl(conn *securityhub.SecurityHub, subscriptions []*securityhub.StandardsSubscription, f func(*securityhub.StandardsSubscription, *securityhub.StandardsControl) bool) error {
+	for _, s := range subscriptions {
 		input := &securityhub.DescribeStandardsControlsInput{
 			StandardsSubscriptionArn: s.StandardsSubscriptionArn,
 		}
 
-		var foundControl *securityhub.StandardsControl
+		done := false
 		err := conn.DescribeStandardsControlsPages(input, func(page *securityhub.DescribeStandardsControlsOutput, lastPage bool) bool {
+			if page == nil {
+				return !lastPage
+			}
+
 			for _, c := range page.Controls {
-				if *c.StandardsControlArn == controlArn {
-					foundControl = c
+				if c == nil {
+					continue
+				}
+
+				if !f(s, c) {
+					done = true
 					return false
 				}
 			}
@@ -58,25 +95,246 @@ func FindSecurityHubControl(conn *securityhub.SecurityHub, controlArn string) (*
 		})
 
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if foundControl != nil {
-			return foundControl, nil
+		if done {
+			return nil
 		}
 	}
 
-	return nil, &resource.NotFoundError{
-		Message: fmt.Sprintf("Could not find a control with arn %s", controlArn),
-	}
+	return nil
 }
 
-func IsSecurityHubOrganizationSettingsAutoEnabled(conn *securityhub.SecurityHub) (bool, error) {
+// FindOrganizationConfiguration returns the Security Hub configuration of the organization of the administrator account.
+func FindOrganizationConfiguration(conn *securityhub.SecurityHub) (*securityhub.DescribeOrganizationConfigurationOutput, error) {
 	input := &securityhub.DescribeOrganizationConfigurationInput{}
-	settings, err := conn.DescribeOrganizationConfiguration(input)
+
+	return conn.DescribeOrganizationConfiguration(input)
+}
+
+// FindMembers returns the member accounts of the administrator account, including the accounts that are no longer
+// associated with it.
+func FindMembers(conn *securityhub.SecurityHub) ([]*securityhub.Member, error) {
+	input := &securityhub.ListMembersInput{
+		OnlyAssociated: aws.Bool(false),
+	}
+	var result []*securityhub.Member
+
+	err := conn.ListMembersPages(input, func(page *securityhub.ListMembersOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, member := range page.Members {
+			if member != nil {
+				result = append(result, member)
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
+
+// FindStandardsControlAssociations returns the associations of a security control, such as EC2.2, with each enabled
+// standard that includes it.
+func FindStandardsControlAssociations(conn *securityhub.SecurityHub, securityControlID string) ([]*securityhub.StandardsControlAssociationSummary, error) {
+	input := &securityhub.ListStandardsControlAssociationsInput{
+		SecurityControlId: aws.String(securityControlID),
+	}
+	var result []*securityhub.StandardsControlAssociationSummary
+
+	err := conn.ListStandardsControlAssociationsPages(input, func(page *securityhub.ListStandardsControlAssociationsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, association := range page.StandardsControlAssociationSummaries {
+			if association != nil {
+				result = append(result, association)
+			}
+		}
+
+		return !lastPage
+	})
+
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	return *settings.AutoEnable, err
+	if len(result) == 0 {
+		return nil, &resource.NotFoundError{
+			Message: fmt.Sprintf("Could not find a control with ID %s in any enabled standard", securityControlID),
+		}
+	}
+
+	return result, nil
+}
+
+// FindEnabledStandards returns the subscriptions to the standards that are enabled in the account.
+func FindEnabledStandards(conn *securityhub.SecurityHub) ([]*securityhub.StandardsSubscription, error) {
+	input := &securityhub.GetEnabledStandardsInput{}
+	var result []*securityhub.StandardsSubscription
+
+	err := conn.GetEnabledStandardsPages(input, func(page *securityhub.GetEnabledStandardsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, subscription := range page.StandardsSubscriptions {
+			if subscription != nil {
+				result = append(result, subscription)
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
+
+// FindEnabledStandardsArns returns the ARNs of the standards that are enabled in the account.
+func FindEnabledStandardsArns(conn *securityhub.SecurityHub) ([]string, error) {
+	subscriptions, err := FindEnabledStandards(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, aws.StringValue(subscription.StandardsArn))
+	}
+
+	return result, nil
+}
+
+// FindSecurityControlIDsByStandard returns the IDs of the security controls that are part of a standard.
+func FindSecurityControlIDsByStandard(conn *securityhub.SecurityHub, standardsArn string) ([]string, error) {
+	input := &securityhub.ListSecurityControlDefinitionsInput{
+		StandardsArn: aws.String(standardsArn),
+	}
+	var result []string
+
+	err := conn.ListSecurityControlDefinitionsPages(input, func(page *securityhub.ListSecurityControlDefinitionsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, definition := range page.SecurityControlDefinitions {
+			if definition != nil {
+				result = append(result, aws.StringValue(definition.SecurityControlId))
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
+
+// forEachFinding calls f with each finding that matches the filters, one page at a time, and stops at the first error
+// returned by f. Throttled requests are retried.
+func forEachFinding(conn *securityhub.SecurityHub, filters *securityhub.AwsSecurityFindingFilters, f func(*securityhub.AwsSecurityFinding) error) error {
+	input := &securityhub.GetFindingsInput{
+		Filters:    filters,
+		MaxResults: aws.Int64(100),
+	}
+
+	for {
+		outputRaw, err := retryWhenSecurityHubThrottled(func() (interface{}, error) {
+			return conn.GetFindings(input)
+		})
+		if err != nil {
+			return err
+		}
+
+		output := outputRaw.(*securityhub.GetFindingsOutput)
+
+		for _, finding := range output.Findings {
+			if finding == nil {
+				continue
+			}
+
+			if err := f(finding); err != nil {
+				return err
+			}
+		}
+
+		if aws.StringValue(output.NextToken) == "" {
+			return nil
+		}
+
+		input.NextToken = output.NextToken
+	}
+}
+
+// FindProducts returns the products that can be integrated with Security Hub in the region.
+func FindProducts(conn *securityhub.SecurityHub) ([]*securityhub.Product, error) {
+	input := &securityhub.DescribeProductsInput{}
+	var result []*securityhub.Product
+
+	err := conn.DescribeProductsPages(input, func(page *securityhub.DescribeProductsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, product := range page.Products {
+			if product != nil {
+				result = append(result, product)
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
+
+// FindEnabledProductSubscriptionArns returns the ARNs of the subscriptions to the products that send findings to
+// Security Hub.
+func FindEnabledProductSubscriptionArns(conn *securityhub.SecurityHub) ([]string, error) {
+	input := &securityhub.ListEnabledProductsForImportInput{}
+	var result []string
+
+	err := conn.ListEnabledProductsForImportPages(input, func(page *securityhub.ListEnabledProductsForImportOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, subscriptionArn := range page.ProductSubscriptions {
+			if subscriptionArn != nil {
+				result = append(result, aws.StringValue(subscriptionArn))
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
+}
+
+// FindFindingAggregatorArn returns the ARN of the finding aggregator of the account, or an empty string if there is
+// none.
+func FindFindingAggregatorArn(conn *securityhub.SecurityHub) (string, error) {
+	input := &securityhub.ListFindingAggregatorsInput{}
+	var result string
+
+	err := conn.ListFindingAggregatorsPages(input, func(page *securityhub.ListFindingAggregatorsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, aggregator := range page.FindingAggregators {
+			if aggregator != nil {
+				result = aws.StringValue(aggregator.FindingAggregatorArn)
+				return false
+			}
+		}
+
+		return !lastPage
+	})
+
+	return result, err
 }
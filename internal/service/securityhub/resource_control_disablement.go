@@ -1,6 +1,7 @@
 package securityhub
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -18,11 +19,15 @@ It can be useful to turn off security checks for controls that are not relevant
 might use a single Amazon S3 bucket to log your CloudTrail logs. If so, you can turn off controls related to CloudTrail 
 logging in all accounts and Regions except for the account and Region where the centralized S3 bucket is located. 
 Disabling irrelevant controls reduces the number of irrelevant findings. It also removes the failed check from the 
-readiness score for the associated standard.`,
+readiness score for the associated standard.
+
+With consolidated control findings, set ` + "`control_id`" + ` to disable a security control, such as ` + "`EC2.2`" + `, in
+every enabled standard at once. The control is disabled again by the next apply when it was enabled in any of them.`,
 		Create:        resourceAwsSecurityHubControlDisablementCreate,
 		Read:          resourceAwsSecurityHubControlDisablementRead,
 		Update:        resourceAwsSecurityHubControlDisablementUpdate,
 		Delete:        resourceAwsSecurityHubControlDisablementDelete,
+		CustomizeDiff: resourceAwsSecurityHubControlDisablementDiff,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -31,10 +36,19 @@ readiness score for the associated standard.`,
 				Computed:    true,
 			},
 			"control_arn": {
-				Description: "The ARN of the Security Hub Standards Control to disable.",
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Required:    true,
+				Description:  "The ARN of the Security Hub Standards Control to disable.",
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"control_arn", "control_id"},
+			},
+			"control_id": {
+				Description: "The ID of the Security Hub security control to disable in every enabled standard, e.g. `EC2.2`. " +
+					"Requires consolidated control findings.",
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"control_arn", "control_id"},
 			},
 			"reason": {
 				Description: "The reason the control is being disabled.",
@@ -42,15 +56,32 @@ readiness score for the associated standard.`,
 				Optional:    true,
 				Default:     "",
 			},
+			"standards_status": {
+				Description: "The status of the control in each enabled standard, by standard ARN. Only set when `control_id` is set.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
 		},
 	}
 }
 
 func resourceAwsSecurityHubControlDisablementCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SecurityHubConn
-	controlArn := d.Get("control_arn").(string)
 	reason := d.Get("reason").(string)
 
+	if controlID := d.Get("control_id").(string); controlID != "" {
+		if err := updateStandardsControlAssociations(conn, controlID, securityhub.AssociationStatusDisabled, reason); err != nil {
+			return err
+		}
+
+		d.SetId(controlID)
+
+		return resourceAwsSecurityHubControlDisablementRead(d, meta)
+	}
+
+	controlArn := d.Get("control_arn").(string)
+
 	input := &securityhub.UpdateStandardsControlInput{
 		StandardsControlArn: &controlArn,
 		ControlStatus:       aws.String("DISABLED"),
@@ -71,6 +102,11 @@ func resourceAwsSecurityHubControlDisablementCreate(d *schema.ResourceData, meta
 
 func resourceAwsSecurityHubControlDisablementRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	if controlID := d.Get("control_id").(string); controlID != "" {
+		return resourceAwsSecurityHubControlDisablementReadByID(d, conn, controlID)
+	}
+
 	controlArn := d.Get("control_arn").(string)
 
 	control, err := FindSecurityHubControl(conn, controlArn)
@@ -92,13 +128,58 @@ func resourceAwsSecurityHubControlDisablementRead(d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceAwsSecurityHubControlDisablementReadByID(d *schema.ResourceData, conn *securityhub.SecurityHub, controlID string) error {
+	associations, err := FindStandardsControlAssociations(conn, controlID)
+	if err != nil {
+		return fmt.Errorf("error reading security hub control %s: %s", controlID, err)
+	}
+
+	standardsStatus := make(map[string]string, len(associations))
+	disabled := false
+
+	for _, association := range associations {
+		standardsStatus[aws.StringValue(association.StandardsArn)] = aws.StringValue(association.AssociationStatus)
+
+		if aws.StringValue(association.AssociationStatus) == securityhub.AssociationStatusDisabled {
+			disabled = true
+		}
+	}
+
+	if !d.IsNewResource() && !disabled {
+		log.Printf("[WARN] Security Hub Control (%s) no longer disabled in any standard, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("reason", standardsControlAssociationsReason(associations, d.Get("reason").(string))); err != nil {
+		return err
+	}
+
+	if err := d.Set("standards_status", standardsStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func resourceAwsSecurityHubControlDisablementUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	if controlID := d.Get("control_id").(string); controlID != "" {
+		// Disables the control again in the standards where it was enabled, and sets the reason in all of them.
+		if err := updateStandardsControlAssociations(conn, controlID, securityhub.AssociationStatusDisabled, d.Get("reason").(string)); err != nil {
+			return err
+		}
+
+		return resourceAwsSecurityHubControlDisablementRead(d, meta)
+	}
+
 	if d.HasChanges("reason") {
-		conn := meta.(*conns.AWSClient).SecurityHubConn
-		controlArn := d.Get("control_arn").(string)
 		_, new := d.GetChange("reason")
 		reason := new.(string)
 
+		controlArn := d.Get("control_arn").(string)
+
 		input := &securityhub.UpdateStandardsControlInput{
 			StandardsControlArn: &controlArn,
 			ControlStatus:       aws.String("DISABLED"),
@@ -115,6 +196,11 @@ func resourceAwsSecurityHubControlDisablementUpdate(d *schema.ResourceData, meta
 
 func resourceAwsSecurityHubControlDisablementDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	if controlID := d.Get("control_id").(string); controlID != "" {
+		return updateStandardsControlAssociations(conn, controlID, securityhub.AssociationStatusEnabled, "")
+	}
+
 	controlArn := d.Get("control_arn").(string)
 
 	input := &securityhub.UpdateStandardsControlInput{
@@ -129,3 +215,75 @@ func resourceAwsSecurityHubControlDisablementDelete(d *schema.ResourceData, meta
 
 	return nil
 }
+
+// updateStandardsControlAssociations sets the status of a security control in every enabled standard that includes it.
+// resourceAwsSecurityHubControlDisablementDiff plans to disable the control again when it was enabled in any of
+// the standards since it was disabled.
+func resourceAwsSecurityHubControlDisablementDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("control_id").(string) == "" {
+		return nil
+	}
+
+	if !standardsControlAssociationsDisabled(d.Get("standards_status").(map[string]interface{})) {
+		return d.SetNewComputed("standards_status")
+	}
+
+	return nil
+}
+
+// standardsControlAssociationsDisabled reports whether the control is disabled in every standard of standards_status.
+func standardsControlAssociationsDisabled(standardsStatus map[string]interface{}) bool {
+	for _, status := range standardsStatus {
+		if status.(string) != securityhub.AssociationStatusDisabled {
+			return false
+		}
+	}
+
+	return true
+}
+
+// standardsControlAssociationsReason returns the configured reason when every disabled association has it. Otherwise,
+// the first reason that differs is returned, so that the difference is planned as an update of the reason.
+func standardsControlAssociationsReason(associations []*securityhub.StandardsControlAssociationSummary, configured string) string {
+	for _, association := range associations {
+		if aws.StringValue(association.AssociationStatus) != securityhub.AssociationStatusDisabled {
+			continue
+		}
+
+		if reason := aws.StringValue(association.UpdatedReason); reason != configured {
+			return reason
+		}
+	}
+
+	return configured
+}
+
+func updateStandardsControlAssociations(conn *securityhub.SecurityHub, controlID, status, reason string) error {
+	associations, err := FindStandardsControlAssociations(conn, controlID)
+	if err != nil {
+		return fmt.Errorf("error reading security hub control %s: %s", controlID, err)
+	}
+
+	var updates []*securityhub.StandardsControlAssociationUpdate
+
+	for _, association := range associations {
+		update := &securityhub.StandardsControlAssociationUpdate{
+			AssociationStatus: aws.String(status),
+			SecurityControlId: aws.String(controlID),
+			StandardsArn:      association.StandardsArn,
+		}
+
+		// A reason can only be given when disabling the control.
+		if status == securityhub.AssociationStatusDisabled && reason != "" {
+			update.UpdatedReason = aws.String(reason)
+		}
+
+		updates = append(updates, update)
+	}
+
+	if err := batchUpdateStandardsControlAssociations(conn, updates); err != nil {
+		return fmt.Errorf("error updating security hub control %s: %s", controlID, err)
+	}
+
+	return nil
+}
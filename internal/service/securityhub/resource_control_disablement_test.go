@@ -0,0 +1,100 @@
+package securityhub
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+)
+
+func TestStandardsControlAssociationsDisabled(t *testing.T) {
+	testCases := []struct {
+		Name            string
+		StandardsStatus map[string]interface{}
+		Expected        bool
+	}{
+		{
+			Name: "all disabled",
+			StandardsStatus: map[string]interface{}{
+				"arn:aws:securityhub:us-east-1::standards/aws-foundational-security-best-practices/v/1.0.0": securityhub.AssociationStatusDisabled,
+				"arn:aws:securityhub:::ruleset/cis-aws-foundations-benchmark/v/1.2.0":                       securityhub.AssociationStatusDisabled,
+			},
+			Expected: true,
+		},
+		{
+			Name: "enabled in one standard",
+			StandardsStatus: map[string]interface{}{
+				"arn:aws:securityhub:us-east-1::standards/aws-foundational-security-best-practices/v/1.0.0": securityhub.AssociationStatusDisabled,
+				"arn:aws:securityhub:::ruleset/cis-aws-foundations-benchmark/v/1.2.0":                       securityhub.AssociationStatusEnabled,
+			},
+		},
+		{
+			Name:     "empty",
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := standardsControlAssociationsDisabled(testCase.StandardsStatus); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestStandardsControlAssociationsReason(t *testing.T) {
+	association := func(status, reason string) *securityhub.StandardsControlAssociationSummary {
+		return &securityhub.StandardsControlAssociationSummary{
+			AssociationStatus: aws.String(status),
+			UpdatedReason:     aws.String(reason),
+		}
+	}
+
+	testCases := []struct {
+		Name         string
+		Associations []*securityhub.StandardsControlAssociationSummary
+		Expected     string
+	}{
+		{
+			Name: "same reason",
+			Associations: []*securityhub.StandardsControlAssociationSummary{
+				association(securityhub.AssociationStatusDisabled, "centralized logging"),
+				association(securityhub.AssociationStatusDisabled, "centralized logging"),
+			},
+			Expected: "centralized logging",
+		},
+		{
+			Name: "different reason in the first standard",
+			Associations: []*securityhub.StandardsControlAssociationSummary{
+				association(securityhub.AssociationStatusDisabled, "manual"),
+				association(securityhub.AssociationStatusDisabled, "centralized logging"),
+			},
+			Expected: "manual",
+		},
+		{
+			Name: "different reason in the last standard",
+			Associations: []*securityhub.StandardsControlAssociationSummary{
+				association(securityhub.AssociationStatusDisabled, "centralized logging"),
+				association(securityhub.AssociationStatusDisabled, "manual"),
+			},
+			Expected: "manual",
+		},
+		{
+			Name: "enabled standard is ignored",
+			Associations: []*securityhub.StandardsControlAssociationSummary{
+				association(securityhub.AssociationStatusDisabled, "centralized logging"),
+				association(securityhub.AssociationStatusEnabled, ""),
+			},
+			Expected: "centralized logging",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := standardsControlAssociationsReason(testCase.Associations, "centralized logging"); got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
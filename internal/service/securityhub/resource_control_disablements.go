@@ -0,0 +1,175 @@
+package securityhub
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceSecurityHubControlDisablements() *schema.Resource {
+	return &schema.Resource{
+		Description: `Disables a set of Security Hub security controls in every enabled standard in the configured region.
+
+This is equivalent to an ` + "`awsutils_security_hub_control_disablement`" + ` per control, but the controls of each enabled
+standard are listed once and cached for all the controls, and the changes are applied in batches, which avoids the rate limits of
+Security Hub when disabling many controls. Requires consolidated control findings.`,
+		Create:        resourceAwsSecurityHubControlDisablementsCreate,
+		Read:          resourceAwsSecurityHubControlDisablementsRead,
+		Update:        resourceAwsSecurityHubControlDisablementsUpdate,
+		Delete:        resourceAwsSecurityHubControlDisablementsDelete,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"controls": {
+				Description: "A map of the IDs of the security controls to disable, e.g. `EC2.2`, to the reason they are disabled.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourceAwsSecurityHubControlDisablementsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	controls := expandControlDisablements(d.Get("controls").(map[string]interface{}))
+
+	if err := updateControlDisablements(conn, nil, controls); err != nil {
+		return err
+	}
+
+	d.SetId(uuid.New().String())
+
+	return resourceAwsSecurityHubControlDisablementsRead(d, meta)
+}
+
+func resourceAwsSecurityHubControlDisablementsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	controls := expandControlDisablements(d.Get("controls").(map[string]interface{}))
+
+	associations, err := findStandardsControlAssociationsByControlIDs(conn, controlDisablementIDs(controls))
+	if err != nil {
+		return err
+	}
+
+	result := make(map[string]string, len(controls))
+
+	for controlID, reason := range controls {
+		disabled := false
+
+		for _, association := range associations[controlID] {
+			if aws.StringValue(association.AssociationStatus) == securityhub.AssociationStatusDisabled {
+				disabled = true
+				reason = aws.StringValue(association.UpdatedReason)
+			}
+		}
+
+		if !d.IsNewResource() && !disabled {
+			log.Printf("[WARN] Security Hub Control (%s) no longer disabled in any standard, removing from state", controlID)
+			continue
+		}
+
+		result[controlID] = reason
+	}
+
+	if err := d.Set("controls", result); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceAwsSecurityHubControlDisablementsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChanges("controls") {
+		conn := meta.(*conns.AWSClient).SecurityHubConn
+		o, n := d.GetChange("controls")
+
+		if err := updateControlDisablements(conn, expandControlDisablements(o.(map[string]interface{})), expandControlDisablements(n.(map[string]interface{}))); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsSecurityHubControlDisablementsRead(d, meta)
+}
+
+func resourceAwsSecurityHubControlDisablementsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	controls := expandControlDisablements(d.Get("controls").(map[string]interface{}))
+
+	return updateControlDisablements(conn, controls, nil)
+}
+
+// updateControlDisablements enables the controls that are no longer disabled, and disables the controls that are
+// newly disabled or whose reason changed.
+func updateControlDisablements(conn *securityhub.SecurityHub, old, new map[string]string) error {
+	var toEnable, toDisable []string
+
+	for controlID := range old {
+		if _, ok := new[controlID]; !ok {
+			toEnable = append(toEnable, controlID)
+		}
+	}
+
+	for controlID, reason := range new {
+		if oldReason, ok := old[controlID]; !ok || oldReason != reason {
+			toDisable = append(toDisable, controlID)
+		}
+	}
+
+	if len(toEnable) == 0 && len(toDisable) == 0 {
+		return nil
+	}
+
+	associations, err := findStandardsControlAssociationsByControlIDs(conn, append(append([]string{}, toEnable...), toDisable...))
+	if err != nil {
+		return err
+	}
+
+	var updates []*securityhub.StandardsControlAssociationUpdate
+
+	for _, controlID := range toEnable {
+		updates = append(updates, associations.updates(controlID, securityhub.AssociationStatusEnabled, "")...)
+	}
+
+	for _, controlID := range toDisable {
+		if len(associations[controlID]) == 0 {
+			return fmt.Errorf("error disabling security hub control %s: not part of any enabled standard", controlID)
+		}
+
+		updates = append(updates, associations.updates(controlID, securityhub.AssociationStatusDisabled, new[controlID])...)
+	}
+
+	return batchUpdateStandardsControlAssociations(conn, updates)
+}
+
+func expandControlDisablements(tfMap map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(tfMap))
+
+	for k, v := range tfMap {
+		result[k] = v.(string)
+	}
+
+	return result
+}
+
+func controlDisablementIDs(controls map[string]string) []string {
+	result := make([]string, 0, len(controls))
+
+	for controlID := range controls {
+		result = append(result, controlID)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,250 @@
+package securityhub
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	linkingModeAllRegions                = "ALL_REGIONS"
+	linkingModeAllRegionsExceptSpecified = "ALL_REGIONS_EXCEPT_SPECIFIED"
+	linkingModeSpecifiedRegions          = "SPECIFIED_REGIONS"
+)
+
+func ResourceSecurityHubFindingAggregatorAdoption() *schema.Resource {
+	return &schema.Resource{
+		Description: `Configures the Security Hub finding aggregator of the account, which aggregates the findings of the linked regions
+in the configured region.
+
+An existing finding aggregator, e.g. one created by AWS Control Tower, is adopted and updated instead of failing to
+create a second one. An adopted or imported finding aggregator is left in place on destroy, and a created one is
+deleted, unless ` + "`retain_on_destroy`" + ` is set.`,
+		Create:        resourceAwsSecurityHubFindingAggregatorAdoptionCreate,
+		Read:          resourceAwsSecurityHubFindingAggregatorAdoptionRead,
+		Update:        resourceAwsSecurityHubFindingAggregatorAdoptionUpdate,
+		Delete:        resourceAwsSecurityHubFindingAggregatorAdoptionDelete,
+		CustomizeDiff: resourceAwsSecurityHubFindingAggregatorAdoptionDiff,
+		SchemaVersion: 1,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// An imported finding aggregator already existed, like an adopted one.
+				d.Set("adopted", true)
+				d.Set("retain_on_destroy", true)
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ARN of the finding aggregator.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"adopted": {
+				Description: "Whether the finding aggregator already existed and was adopted, rather than created.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"finding_aggregation_region": {
+				Description: "The region the findings are aggregated in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"linking_mode": {
+				Description: "Which regions to aggregate the findings of. Valid values are `ALL_REGIONS`, " +
+					"`ALL_REGIONS_EXCEPT_SPECIFIED` and `SPECIFIED_REGIONS`.",
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					linkingModeAllRegions,
+					linkingModeAllRegionsExceptSpecified,
+					linkingModeSpecifiedRegions,
+				}, false),
+			},
+			"retain_on_destroy": {
+				Description: "Whether to leave the finding aggregator in place when the resource is destroyed. " +
+					"Defaults to `true` when the finding aggregator is adopted or imported, and `false` when it is created.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"specified_regions": {
+				Description: "The regions to aggregate or exclude the findings of, with the `SPECIFIED_REGIONS` and " +
+					"`ALL_REGIONS_EXCEPT_SPECIFIED` linking modes.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSecurityHubFindingAggregatorAdoptionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	linkingMode := d.Get("linking_mode").(string)
+	regions := flex.ExpandStringSet(d.Get("specified_regions").(*schema.Set))
+	retainOnDestroyConfigured := !d.GetRawConfig().GetAttr("retain_on_destroy").IsNull()
+
+	aggregatorArn, err := FindFindingAggregatorArn(conn)
+	if err != nil {
+		return fmt.Errorf("error reading security hub finding aggregators: %s", err)
+	}
+
+	if aggregatorArn != "" {
+		log.Printf("[INFO] Adopting existing Security Hub finding aggregator (%s)", aggregatorArn)
+
+		if err := updateFindingAggregator(conn, aggregatorArn, linkingMode, regions); err != nil {
+			return err
+		}
+
+		d.SetId(aggregatorArn)
+		d.Set("adopted", true)
+
+		// The aggregator is likely managed elsewhere, e.g. by AWS Control Tower, so it is kept unless told otherwise.
+		if !retainOnDestroyConfigured {
+			d.Set("retain_on_destroy", true)
+		}
+
+		return resourceAwsSecurityHubFindingAggregatorAdoptionRead(d, meta)
+	}
+
+	input := &securityhub.CreateFindingAggregatorInput{
+		RegionLinkingMode: aws.String(linkingMode),
+	}
+	if len(regions) > 0 {
+		input.Regions = regions
+	}
+
+	output, err := conn.CreateFindingAggregator(input)
+	if err != nil {
+		return fmt.Errorf("error creating security hub finding aggregator: %s", err)
+	}
+
+	d.SetId(aws.StringValue(output.FindingAggregatorArn))
+	d.Set("adopted", false)
+
+	if !retainOnDestroyConfigured {
+		d.Set("retain_on_destroy", false)
+	}
+
+	return resourceAwsSecurityHubFindingAggregatorAdoptionRead(d, meta)
+}
+
+func resourceAwsSecurityHubFindingAggregatorAdoptionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	output, err := conn.GetFindingAggregator(&securityhub.GetFindingAggregatorInput{
+		FindingAggregatorArn: aws.String(d.Id()),
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, securityhub.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Security Hub finding aggregator (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error reading security hub finding aggregator (%s): %s", d.Id(), err)
+	}
+
+	d.Set("finding_aggregation_region", output.FindingAggregationRegion)
+	d.Set("linking_mode", output.RegionLinkingMode)
+
+	// The regions that are linked with the ALL_REGIONS linking mode are not part of the configuration.
+	var regions []string
+	if aws.StringValue(output.RegionLinkingMode) != linkingModeAllRegions {
+		regions = aws.StringValueSlice(output.Regions)
+	}
+
+	if err := d.Set("specified_regions", regions); err != nil {
+		return fmt.Errorf("error setting specified_regions: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsSecurityHubFindingAggregatorAdoptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	if d.HasChanges("linking_mode", "specified_regions") {
+		linkingMode := d.Get("linking_mode").(string)
+		regions := flex.ExpandStringSet(d.Get("specified_regions").(*schema.Set))
+
+		if err := updateFindingAggregator(conn, d.Id(), linkingMode, regions); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsSecurityHubFindingAggregatorAdoptionRead(d, meta)
+}
+
+func resourceAwsSecurityHubFindingAggregatorAdoptionDelete(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("retain_on_destroy").(bool) {
+		log.Printf("[INFO] Retaining Security Hub finding aggregator (%s)", d.Id())
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	_, err := conn.DeleteFindingAggregator(&securityhub.DeleteFindingAggregatorInput{
+		FindingAggregatorArn: aws.String(d.Id()),
+	})
+
+	if tfawserr.ErrCodeEquals(err, securityhub.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting security hub finding aggregator (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceAwsSecurityHubFindingAggregatorAdoptionDiff reports at plan time the regions that do not match the linking mode.
+func resourceAwsSecurityHubFindingAggregatorAdoptionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("linking_mode") || !d.NewValueKnown("specified_regions") {
+		return nil
+	}
+
+	return validateFindingAggregatorRegions(d.Get("linking_mode").(string), flex.ExpandStringSet(d.Get("specified_regions").(*schema.Set)))
+}
+
+func updateFindingAggregator(conn *securityhub.SecurityHub, aggregatorArn, linkingMode string, regions []*string) error {
+	input := &securityhub.UpdateFindingAggregatorInput{
+		FindingAggregatorArn: aws.String(aggregatorArn),
+		RegionLinkingMode:    aws.String(linkingMode),
+	}
+	if len(regions) > 0 {
+		input.Regions = regions
+	}
+
+	if _, err := conn.UpdateFindingAggregator(input); err != nil {
+		return fmt.Errorf("error updating security hub finding aggregator (%s): %s", aggregatorArn, err)
+	}
+
+	return nil
+}
+
+// validateFindingAggregatorRegions checks that regions are given with, and only with, the linking modes that use them.
+func validateFindingAggregatorRegions(linkingMode string, regions []*string) error {
+	if linkingMode == linkingModeAllRegions && len(regions) > 0 {
+		return fmt.Errorf("specified_regions must be empty when linking_mode is %s", linkingModeAllRegions)
+	}
+
+	if linkingMode != linkingModeAllRegions && len(regions) == 0 {
+		return fmt.Errorf("specified_regions must not be empty when linking_mode is %s", linkingMode)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package securityhub
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestValidateFindingAggregatorRegions(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		LinkingMode string
+		Regions     []string
+		ExpectError bool
+	}{
+		{
+			Name:        "all regions",
+			LinkingMode: linkingModeAllRegions,
+		},
+		{
+			Name:        "all regions with regions",
+			LinkingMode: linkingModeAllRegions,
+			Regions:     []string{"us-east-2"},
+			ExpectError: true,
+		},
+		{
+			Name:        "specified regions",
+			LinkingMode: linkingModeSpecifiedRegions,
+			Regions:     []string{"us-east-2", "us-west-2"},
+		},
+		{
+			Name:        "specified regions without regions",
+			LinkingMode: linkingModeSpecifiedRegions,
+			ExpectError: true,
+		},
+		{
+			Name:        "all regions except specified without regions",
+			LinkingMode: linkingModeAllRegionsExceptSpecified,
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := validateFindingAggregatorRegions(testCase.LinkingMode, aws.StringSlice(testCase.Regions))
+
+			if testCase.ExpectError != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.ExpectError, err)
+			}
+		})
+	}
+}
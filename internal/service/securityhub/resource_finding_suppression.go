@@ -0,0 +1,278 @@
+package securityhub
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// findingsBatchSize is the number of findings updated per request.
+	findingsBatchSize = 100
+
+	defaultFindingSuppressionUpdatedBy = "terraform"
+)
+
+// findingSuppressionFilterKeys are the attributes of the filter, at least one of which must be set.
+var findingSuppressionFilterKeys = []string{
+	"filter.0.compliance_statuses",
+	"filter.0.generator_ids",
+	"filter.0.product_arns",
+	"filter.0.product_names",
+	"filter.0.resource_ids",
+	"filter.0.resource_tags",
+}
+
+func ResourceSecurityHubFindingSuppression() *schema.Resource {
+	return &schema.Resource{
+		Description: `Suppresses the active Security Hub findings that match a filter in the configured region, by setting their
+workflow status to ` + "`SUPPRESSED`" + ` and adding a note.
+
+The findings are searched again on every refresh, so that new findings that match the filter are suppressed too. Only
+findings with a ` + "`NEW`" + ` or ` + "`NOTIFIED`" + ` workflow status are suppressed. The findings remain suppressed when the
+resource is destroyed.`,
+		Create:        resourceAwsSecurityHubFindingSuppressionCreate,
+		Read:          resourceAwsSecurityHubFindingSuppressionRead,
+		Update:        resourceAwsSecurityHubFindingSuppressionUpdate,
+		Delete:        resourceAwsSecurityHubFindingSuppressionDelete,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"filter": {
+				Description: "The filter of the findings to suppress. A finding must match every attribute that is set, " +
+					"and any of the values of an attribute.",
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"compliance_statuses": {
+							Description: "The compliance statuses of the findings. Valid values are `PASSED`, `WARNING`, " +
+								"`FAILED` and `NOT_AVAILABLE`.",
+							Type: schema.TypeSet,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(securityhub.ComplianceStatus_Values(), false),
+							},
+							Set:          schema.HashString,
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+						"generator_ids": {
+							Description:  "The IDs of the solution-specific components that generated the findings, e.g. a control ID.",
+							Type:         schema.TypeSet,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Set:          schema.HashString,
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+						"product_arns": {
+							Description:  "The ARNs of the products that generated the findings.",
+							Type:         schema.TypeSet,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Set:          schema.HashString,
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+						"product_names": {
+							Description:  "The names of the products that generated the findings, e.g. `Security Hub` or `GuardDuty`.",
+							Type:         schema.TypeSet,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Set:          schema.HashString,
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+						"resource_ids": {
+							Description:  "The IDs of the resources the findings are about, e.g. ARNs.",
+							Type:         schema.TypeSet,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Set:          schema.HashString,
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+						"resource_tags": {
+							Description:  "The tags of the resources the findings are about. A resource must have every tag.",
+							Type:         schema.TypeMap,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							Optional:     true,
+							AtLeastOneOf: findingSuppressionFilterKeys,
+						},
+					},
+				},
+			},
+			"note": {
+				Description:  "The note added to the suppressed findings.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 512),
+			},
+			"suppressed_finding_count": {
+				Description: "The number of findings suppressed by the last apply or refresh.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"updated_by": {
+				Description:  "The author of the note added to the suppressed findings.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultFindingSuppressionUpdatedBy,
+				ValidateFunc: validation.StringLenBetween(1, 512),
+			},
+		},
+	}
+}
+
+func resourceAwsSecurityHubFindingSuppressionCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(uuid.New().String())
+
+	return resourceAwsSecurityHubFindingSuppressionRead(d, meta)
+}
+
+func resourceAwsSecurityHubFindingSuppressionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	filters := expandFindingSuppressionFilters(d.Get("filter").([]interface{}))
+	note := &securityhub.NoteUpdate{
+		Text:      aws.String(d.Get("note").(string)),
+		UpdatedBy: aws.String(d.Get("updated_by").(string)),
+	}
+
+	// The findings are suppressed as they are read, so that they are not all held in memory. A finding that is
+	// skipped because suppressing the previous ones shifted the pages is suppressed by the next refresh.
+	var batch []*securityhub.AwsSecurityFindingIdentifier
+	count := 0
+
+	err := forEachFinding(conn, filters, func(finding *securityhub.AwsSecurityFinding) error {
+		batch = append(batch, &securityhub.AwsSecurityFindingIdentifier{
+			Id:         finding.Id,
+			ProductArn: finding.ProductArn,
+		})
+		count++
+
+		if len(batch) < findingsBatchSize {
+			return nil
+		}
+
+		identifiers := batch
+		batch = nil
+
+		return suppressSecurityHubFindings(conn, identifiers, note)
+	})
+
+	if err == nil && len(batch) > 0 {
+		err = suppressSecurityHubFindings(conn, batch, note)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error suppressing security hub findings: %s", err)
+	}
+
+	if count > 0 {
+		log.Printf("[DEBUG] Suppressed %d Security Hub findings", count)
+	}
+
+	d.Set("suppressed_finding_count", count)
+
+	return nil
+}
+
+func resourceAwsSecurityHubFindingSuppressionUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceAwsSecurityHubFindingSuppressionRead(d, meta)
+}
+
+func resourceAwsSecurityHubFindingSuppressionDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// expandFindingSuppressionFilters returns the filters of the active findings that match the filter of the resource and
+// are not suppressed or resolved yet.
+func expandFindingSuppressionFilters(tfList []interface{}) *securityhub.AwsSecurityFindingFilters {
+	filters := &securityhub.AwsSecurityFindingFilters{
+		RecordState:    expandStringFilters([]string{securityhub.RecordStateActive}),
+		WorkflowStatus: expandStringFilters([]string{securityhub.WorkflowStatusNew, securityhub.WorkflowStatusNotified}),
+	}
+
+	if len(tfList) == 0 || tfList[0] == nil {
+		return filters
+	}
+
+	tfMap := tfList[0].(map[string]interface{})
+
+	filters.ComplianceStatus = expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(tfMap["compliance_statuses"].(*schema.Set))))
+	filters.GeneratorId = expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(tfMap["generator_ids"].(*schema.Set))))
+	filters.ProductArn = expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(tfMap["product_arns"].(*schema.Set))))
+	filters.ProductName = expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(tfMap["product_names"].(*schema.Set))))
+	filters.ResourceId = expandStringFilters(flex.ExpandStringSliceofPointers(flex.ExpandStringSet(tfMap["resource_ids"].(*schema.Set))))
+
+	for key, value := range tfMap["resource_tags"].(map[string]interface{}) {
+		filters.ResourceTags = append(filters.ResourceTags, &securityhub.MapFilter{
+			Comparison: aws.String(securityhub.MapFilterComparisonEquals),
+			Key:        aws.String(key),
+			Value:      aws.String(value.(string)),
+		})
+	}
+
+	return filters
+}
+
+// expandStringFilters returns filters that match any of the values.
+func expandStringFilters(values []string) []*securityhub.StringFilter {
+	var result []*securityhub.StringFilter
+
+	for _, value := range values {
+		result = append(result, &securityhub.StringFilter{
+			Comparison: aws.String(securityhub.StringFilterComparisonEquals),
+			Value:      aws.String(value),
+		})
+	}
+
+	return result
+}
+
+// suppressSecurityHubFindings sets the workflow status of at most findingsBatchSize findings to SUPPRESSED, and adds
+// the note to them. Throttled requests are retried.
+func suppressSecurityHubFindings(conn *securityhub.SecurityHub, identifiers []*securityhub.AwsSecurityFindingIdentifier, note *securityhub.NoteUpdate) error {
+	input := &securityhub.BatchUpdateFindingsInput{
+		FindingIdentifiers: identifiers,
+		Note:               note,
+		Workflow: &securityhub.WorkflowUpdate{
+			Status: aws.String(securityhub.WorkflowStatusSuppressed),
+		},
+	}
+
+	outputRaw, err := retryWhenSecurityHubThrottled(func() (interface{}, error) {
+		return conn.BatchUpdateFindings(input)
+	})
+	if err != nil {
+		return err
+	}
+
+	output := outputRaw.(*securityhub.BatchUpdateFindingsOutput)
+
+	if len(output.UnprocessedFindings) > 0 {
+		var errs []string
+		for _, unprocessed := range output.UnprocessedFindings {
+			errs = append(errs, fmt.Sprintf("%s: %s (%s)",
+				aws.StringValue(unprocessed.FindingIdentifier.Id),
+				aws.StringValue(unprocessed.ErrorCode),
+				aws.StringValue(unprocessed.ErrorMessage),
+			))
+		}
+
+		return fmt.Errorf("unprocessed findings: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
@@ -1,27 +1,51 @@
 package securityhub
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/securityhub"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
 	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// inactiveMemberStatuses are the statuses of the member accounts that are no longer associated with the Security Hub
+// Administrator account.
+var inactiveMemberStatuses = []string{"Removed", "Resigned", "Deleted", "AccountSuspended"}
+
+// organizationConfigurationTimeout is how long to wait for a change of the configuration type of the organization.
+const organizationConfigurationTimeout = 5 * time.Minute
+
+// securityHubMembersBatchSize is the number of accounts the Security Hub member APIs accept per request.
+const securityHubMembersBatchSize = 50
+
 func ResourceSecurityHubOrganizationSettings() *schema.Resource {
 	return &schema.Resource{
 		Description: `Enables a list of accounts as Security Hub member accounts in an existing AWS Organization.
 
 Designating an account as the Security Hub Administrator account in an AWS Organization can optionally enable all 
 newly created accounts and accounts that join the organization after the setting is enabled, however it does not 
-enable existing accounts. Use this resource to enable a list of existing accounts`,
+enable existing accounts. Use this resource to enable a list of existing accounts.
+
+Accounts of ` + "`member_accounts`" + ` that are removed from Security Hub outside of Terraform are detected and added
+back. Members that are not in ` + "`member_accounts`" + `, such as automatically enabled accounts, are listed in
+` + "`members`" + ` but are not removed.
+
+Set ` + "`configuration_type`" + ` to ` + "`CENTRAL`" + ` to configure the member accounts with configuration policies of the
+delegated administrator instead, in which case new accounts and their standards are no longer enabled automatically.`,
 		Create:        resourceAwsSecurityHubOrganizationSettingsCreate,
 		Read:          resourceAwsSecurityHubOrganizationSettingsRead,
 		Update:        resourceAwsSecurityHubOrganizationSettingsUpdate,
 		Delete:        resourceAwsSecurityHubOrganizationSettingsDelete,
+		CustomizeDiff: resourceAwsSecurityHubOrganizationSettingsDiff,
 		SchemaVersion: 1,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -42,6 +66,47 @@ enable existing accounts. Use this resource to enable a list of existing account
 				Optional:    true,
 				Default:     true,
 			},
+			"auto_enable_standards": {
+				Description: "Whether to automatically enable the default standards in new member accounts. " +
+					"Valid values are `DEFAULT` and `NONE`. Left unchanged if not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(securityhub.AutoEnableStandards_Values(), false),
+			},
+			"configuration_type": {
+				Description: "How Security Hub is configured in the member accounts. Valid values are `CENTRAL`, with the " +
+					"configuration policies of the delegated administrator, which requires `auto_enable_new_accounts` to be " +
+					"`false` and `auto_enable_standards` to be `NONE`, and `LOCAL`, by each account. Left unchanged if not set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(securityhub.OrganizationConfigurationConfigurationType_Values(), false),
+			},
+			"disassociate_members_on_destroy": {
+				Description: "Whether to disassociate and delete the accounts of `member_accounts` when the resource is destroyed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"members": {
+				Description: "All the member accounts of the Security Hub Administrator account, including the accounts " +
+					"that are not in `member_accounts`.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"member_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -50,12 +115,14 @@ func resourceAwsSecurityHubOrganizationSettingsCreate(d *schema.ResourceData, me
 	conn := meta.(*conns.AWSClient).SecurityHubConn
 	memberAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set)))
 	autoEnable := d.Get("auto_enable_new_accounts").(bool)
+	autoEnableStandards := d.Get("auto_enable_standards").(string)
+	configurationType := d.Get("configuration_type").(string)
 
 	if err := addSecurityHubOrganizationMembers(conn, memberAccounts); err != nil {
 		return err
 	}
 
-	if err := updateSecurityHubOrganizationSettings(conn, autoEnable); err != nil {
+	if err := updateSecurityHubOrganizationSettings(conn, autoEnable, autoEnableStandards, configurationType); err != nil {
 		return err
 	}
 
@@ -67,23 +134,72 @@ func resourceAwsSecurityHubOrganizationSettingsCreate(d *schema.ResourceData, me
 func resourceAwsSecurityHubOrganizationSettingsRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SecurityHubConn
 
-	enabled, err := IsSecurityHubOrganizationSettingsAutoEnabled(conn)
+	settings, err := FindOrganizationConfiguration(conn)
 	if err != nil {
 		return fmt.Errorf("error reading security hub organization settings: %s", err)
 	}
 
-	d.Set("auto_enable_new_accounts", enabled)
+	d.Set("auto_enable_new_accounts", settings.AutoEnable)
+	d.Set("auto_enable_standards", settings.AutoEnableStandards)
+
+	if settings.OrganizationConfiguration != nil {
+		d.Set("configuration_type", settings.OrganizationConfiguration.ConfigurationType)
+	} else {
+		d.Set("configuration_type", nil)
+	}
+
+	members, err := FindMembers(conn)
+	if err != nil {
+		return fmt.Errorf("error reading security hub administrator account members: %s", err)
+	}
+
+	active := make(map[string]bool, len(members))
+	tfList := make([]interface{}, 0, len(members))
+
+	for _, member := range members {
+		accountID := aws.StringValue(member.AccountId)
+		status := aws.StringValue(member.MemberStatus)
+
+		if isActiveSecurityHubMemberStatus(status) {
+			active[accountID] = true
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"account_id":    accountID,
+			"member_status": status,
+		})
+	}
+
+	if err := d.Set("members", tfList); err != nil {
+		return fmt.Errorf("error setting members: %s", err)
+	}
+
+	// A member account that is no longer associated is removed from the state, so that it is added back.
+	var memberAccounts []string
+	for _, accountID := range flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set))) {
+		if !active[accountID] {
+			log.Printf("[WARN] Account %s is no longer a Security Hub member", accountID)
+			continue
+		}
+
+		memberAccounts = append(memberAccounts, accountID)
+	}
+
+	if err := d.Set("member_accounts", memberAccounts); err != nil {
+		return fmt.Errorf("error setting member_accounts: %s", err)
+	}
 
 	return nil
 }
 
 func resourceAwsSecurityHubOrganizationSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*conns.AWSClient).SecurityHubConn
-	if d.HasChanges("auto_enable_new_accounts") {
-		_, new := d.GetChange("auto_enable_new_accounts")
-		autoEnable := new.(bool)
+	if d.HasChanges("auto_enable_new_accounts", "auto_enable_standards", "configuration_type") {
+		autoEnable := d.Get("auto_enable_new_accounts").(bool)
+		autoEnableStandards := d.Get("auto_enable_standards").(string)
+		configurationType := d.Get("configuration_type").(string)
 
-		if err := updateSecurityHubOrganizationSettings(conn, autoEnable); err != nil {
+		if err := updateSecurityHubOrganizationSettings(conn, autoEnable, autoEnableStandards, configurationType); err != nil {
 			return fmt.Errorf("error updating security hub organization settings: %s", err)
 		}
 	}
@@ -108,23 +224,111 @@ func resourceAwsSecurityHubOrganizationSettingsUpdate(d *schema.ResourceData, me
 			}
 		}
 	}
-	return nil
+
+	return resourceAwsSecurityHubOrganizationSettingsRead(d, meta)
 }
 
 func resourceAwsSecurityHubOrganizationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	if !d.Get("disassociate_members_on_destroy").(bool) {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	memberAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set)))
+
+	if err := removeSecurityHubOrganizationMembers(conn, memberAccounts); err != nil {
+		return fmt.Errorf("error removing security hub organization members: %s", err)
+	}
+
 	return nil
 }
 
-func updateSecurityHubOrganizationSettings(conn *securityhub.SecurityHub, autoEnable bool) error {
+// resourceAwsSecurityHubOrganizationSettingsDiff reports at plan time the settings that Security Hub rejects with
+// central configuration.
+func resourceAwsSecurityHubOrganizationSettingsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("configuration_type").(string) != securityhub.OrganizationConfigurationConfigurationTypeCentral {
+		return nil
+	}
+
+	if d.Get("auto_enable_new_accounts").(bool) {
+		return fmt.Errorf("auto_enable_new_accounts must be false when configuration_type is %s", securityhub.OrganizationConfigurationConfigurationTypeCentral)
+	}
+
+	if v := d.Get("auto_enable_standards").(string); v != "" && v != securityhub.AutoEnableStandardsNone {
+		return fmt.Errorf("auto_enable_standards must be %s when configuration_type is %s", securityhub.AutoEnableStandardsNone, securityhub.OrganizationConfigurationConfigurationTypeCentral)
+	}
+
+	return nil
+}
+
+// updateSecurityHubOrganizationSettings updates the automatic enablement of new accounts, and of the default standards
+// in new accounts unless autoEnableStandards is empty, and the configuration type unless configurationType is empty.
+func updateSecurityHubOrganizationSettings(conn *securityhub.SecurityHub, autoEnable bool, autoEnableStandards, configurationType string) error {
 	updateOrgSettingsInput := &securityhub.UpdateOrganizationConfigurationInput{
 		AutoEnable: aws.Bool(autoEnable),
 	}
+	if autoEnableStandards != "" {
+		updateOrgSettingsInput.AutoEnableStandards = aws.String(autoEnableStandards)
+	} else if configurationType == securityhub.OrganizationConfigurationConfigurationTypeCentral {
+		// Central configuration is rejected unless the default standards are not enabled automatically.
+		updateOrgSettingsInput.AutoEnableStandards = aws.String(securityhub.AutoEnableStandardsNone)
+	}
+	if configurationType != "" {
+		updateOrgSettingsInput.OrganizationConfiguration = &securityhub.OrganizationConfiguration{
+			ConfigurationType: aws.String(configurationType),
+		}
+	}
 	if _, err := conn.UpdateOrganizationConfiguration(updateOrgSettingsInput); err != nil {
 		return fmt.Errorf("error updating security hub administrator account settings: %s", err)
 	}
+	if configurationType == "" {
+		return nil
+	}
+	return waitSecurityHubOrganizationConfiguration(conn, configurationType)
+}
+
+// waitSecurityHubOrganizationConfiguration waits for the configuration type of the organization to be enabled.
+func waitSecurityHubOrganizationConfiguration(conn *securityhub.SecurityHub, configurationType string) error {
+	err := tfresource.WaitUntil(organizationConfigurationTimeout, func() (bool, error) {
+		settings, err := FindOrganizationConfiguration(conn)
+		if err != nil {
+			return false, err
+		}
+
+		configuration := settings.OrganizationConfiguration
+		if configuration == nil || aws.StringValue(configuration.ConfigurationType) != configurationType {
+			return false, nil
+		}
+
+		switch aws.StringValue(configuration.Status) {
+		case securityhub.OrganizationConfigurationStatusFailed:
+			return false, fmt.Errorf("%s configuration failed: %s", configurationType, aws.StringValue(configuration.StatusMessage))
+		case securityhub.OrganizationConfigurationStatusPending:
+			return false, nil
+		default:
+			return true, nil
+		}
+	}, tfresource.WaitOpts{
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error waiting for security hub organization configuration: %s", err)
+	}
+
 	return nil
 }
 
+func isActiveSecurityHubMemberStatus(status string) bool {
+	for _, inactive := range inactiveMemberStatuses {
+		if strings.EqualFold(status, inactive) {
+			return false
+		}
+	}
+	return true
+}
+
 func makeAccountDetails(accounts []string) []*securityhub.AccountDetails {
 	accountDetails := make([]*securityhub.AccountDetails, 0)
 	for i := range accounts {
@@ -141,12 +345,27 @@ func makeAccountIDs(accounts []string) []*string {
 	return accountIDs
 }
 
-func addSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, memberAccounts []string) error {
-	if len(memberAccounts) > 0 {
-		accountDetails := makeAccountDetails(memberAccounts)
+// chunkSecurityHubAccounts splits the accounts in batches of at most securityHubMembersBatchSize.
+func chunkSecurityHubAccounts(accounts []string) [][]string {
+	var batches [][]string
 
+	for len(accounts) > 0 {
+		n := len(accounts)
+		if n > securityHubMembersBatchSize {
+			n = securityHubMembersBatchSize
+		}
+
+		batches = append(batches, accounts[:n])
+		accounts = accounts[n:]
+	}
+
+	return batches
+}
+
+func addSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, memberAccounts []string) error {
+	for _, batch := range chunkSecurityHubAccounts(memberAccounts) {
 		createMembersInput := &securityhub.CreateMembersInput{
-			AccountDetails: accountDetails,
+			AccountDetails: makeAccountDetails(batch),
 		}
 
 		if result, err := conn.CreateMembers(createMembersInput); err != nil || len(result.UnprocessedAccounts) > 0 {
@@ -160,8 +379,9 @@ func addSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, memberAcco
 }
 
 func removeSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, memberAccounts []string) error {
-	accountIDs := makeAccountIDs(memberAccounts)
-	if len(memberAccounts) > 0 {
+	for _, batch := range chunkSecurityHubAccounts(memberAccounts) {
+		accountIDs := makeAccountIDs(batch)
+
 		disassociateMembersInput := &securityhub.DisassociateMembersInput{
 			AccountIds: accountIDs,
 		}
@@ -171,9 +391,7 @@ func removeSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, memberA
 		}
 
 		if _, err := conn.DisassociateMembers(disassociateMembersInput); err != nil {
-			if err != nil {
-				return fmt.Errorf("error disassociating security hub administrator account members: %s", err)
-			}
+			return fmt.Errorf("error disassociating security hub administrator account members: %s", err)
 		}
 
 		if result, err := conn.DeleteMembers(deleteMembersInput); err != nil || len(result.UnprocessedAccounts) > 0 {
@@ -0,0 +1,58 @@
+package securityhub
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestChunkSecurityHubAccounts(t *testing.T) {
+	accounts := func(n int) []string {
+		var result []string
+		for i := 0; i < n; i++ {
+			result = append(result, fmt.Sprintf("%012d", i))
+		}
+		return result
+	}
+
+	testCases := []struct {
+		Name     string
+		Accounts []string
+		Expected []int
+	}{
+		{
+			Name: "none",
+		},
+		{
+			Name:     "one batch",
+			Accounts: accounts(50),
+			Expected: []int{50},
+		},
+		{
+			Name:     "several batches",
+			Accounts: accounts(120),
+			Expected: []int{50, 50, 20},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			batches := chunkSecurityHubAccounts(testCase.Accounts)
+
+			var sizes []int
+			var got []string
+			for _, batch := range batches {
+				sizes = append(sizes, len(batch))
+				got = append(got, batch...)
+			}
+
+			if !reflect.DeepEqual(sizes, testCase.Expected) {
+				t.Errorf("expected batch sizes %v, got %v", testCase.Expected, sizes)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Accounts) {
+				t.Errorf("expected the batches to hold every account in order")
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package securityhub
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// defaultProductCompany is the company of the products given by their name only.
+	defaultProductCompany = "aws"
+
+	productArnResourcePrefix             = "product/"
+	productSubscriptionArnResourcePrefix = "product-subscription/"
+)
+
+func ResourceSecurityHubProductSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		Description: `Enables a set of Security Hub product integrations in the configured region, so that the products send their
+findings to Security Hub.
+
+Products are given by their name, e.g. ` + "`guardduty`" + `, ` + "`inspector`" + `, ` + "`macie`" + `, ` + "`config`" + ` or
+` + "`access-analyzer`" + ` for the AWS products, by their company and name, e.g. ` + "`crowdstrike/crowdstrike-falcon`" + `,
+or by their product ARN. The product ARNs of the region are looked up with ` + "`DescribeProducts`" + `. The integrations
+are disabled on destroy.`,
+		Create:        resourceAwsSecurityHubProductSubscriptionsCreate,
+		Read:          resourceAwsSecurityHubProductSubscriptionsRead,
+		Update:        resourceAwsSecurityHubProductSubscriptionsUpdate,
+		Delete:        resourceAwsSecurityHubProductSubscriptionsDelete,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"product_subscription_arns": {
+				Description: "A map of the products to the ARNs of their subscriptions.",
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"products": {
+				Description: "A set of the products to enable, by name, company and name, or product ARN.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Set:      schema.HashString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSecurityHubProductSubscriptionsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	products := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("products").(*schema.Set)))
+
+	if err := updateProductSubscriptions(conn, products, nil); err != nil {
+		return err
+	}
+
+	d.SetId(uuid.New().String())
+
+	return resourceAwsSecurityHubProductSubscriptionsRead(d, meta)
+}
+
+func resourceAwsSecurityHubProductSubscriptionsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	products := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("products").(*schema.Set)))
+
+	subscriptions, err := findProductSubscriptionArnsByKey(conn)
+	if err != nil {
+		return err
+	}
+
+	var enabled []string
+	subscriptionArns := make(map[string]string, len(products))
+
+	for _, product := range products {
+		subscriptionArn, ok := subscriptions[productKey(product)]
+		if !ok {
+			log.Printf("[WARN] Security Hub product (%s) no longer enabled, removing from state", product)
+			continue
+		}
+
+		enabled = append(enabled, product)
+		subscriptionArns[product] = subscriptionArn
+	}
+
+	if err := d.Set("products", enabled); err != nil {
+		return fmt.Errorf("error setting products: %s", err)
+	}
+
+	if err := d.Set("product_subscription_arns", subscriptionArns); err != nil {
+		return fmt.Errorf("error setting product_subscription_arns: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsSecurityHubProductSubscriptionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+
+	o, n := d.GetChange("products")
+	oldProducts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(o.(*schema.Set)))
+	newProducts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(n.(*schema.Set)))
+
+	if err := updateProductSubscriptions(conn, newProducts, oldProducts); err != nil {
+		return err
+	}
+
+	return resourceAwsSecurityHubProductSubscriptionsRead(d, meta)
+}
+
+func resourceAwsSecurityHubProductSubscriptionsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	products := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("products").(*schema.Set)))
+
+	return updateProductSubscriptions(conn, nil, products)
+}
+
+// updateProductSubscriptions enables the wanted products that are not enabled yet, and disables the previously enabled
+// products that are no longer wanted.
+func updateProductSubscriptions(conn *securityhub.SecurityHub, wanted, previous []string) error {
+	subscriptions, err := findProductSubscriptionArnsByKey(conn)
+	if err != nil {
+		return err
+	}
+
+	wantedKeys := make(map[string]bool, len(wanted))
+	var toEnable []string
+
+	for _, product := range wanted {
+		key := productKey(product)
+		wantedKeys[key] = true
+
+		if _, ok := subscriptions[key]; !ok {
+			toEnable = append(toEnable, product)
+		}
+	}
+
+	if len(toEnable) > 0 {
+		productArns, err := findProductArnsByKey(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range toEnable {
+			productArn, ok := productArns[productKey(product)]
+			if !ok {
+				return fmt.Errorf("error enabling security hub product %s: product not found in the region", product)
+			}
+
+			log.Printf("[DEBUG] Enabling Security Hub product (%s)", productArn)
+
+			_, err := conn.EnableImportFindingsForProduct(&securityhub.EnableImportFindingsForProductInput{
+				ProductArn: aws.String(productArn),
+			})
+			if err != nil {
+				return fmt.Errorf("error enabling security hub product %s: %s", product, err)
+			}
+		}
+	}
+
+	for _, product := range previous {
+		key := productKey(product)
+		if wantedKeys[key] {
+			continue
+		}
+
+		subscriptionArn, ok := subscriptions[key]
+		if !ok {
+			continue
+		}
+
+		log.Printf("[DEBUG] Disabling Security Hub product (%s)", subscriptionArn)
+
+		_, err := conn.DisableImportFindingsForProduct(&securityhub.DisableImportFindingsForProductInput{
+			ProductSubscriptionArn: aws.String(subscriptionArn),
+		})
+		if err != nil {
+			return fmt.Errorf("error disabling security hub product %s: %s", product, err)
+		}
+	}
+
+	return nil
+}
+
+// findProductArnsByKey returns the ARNs of the products of the region, by product key.
+func findProductArnsByKey(conn *securityhub.SecurityHub) (map[string]string, error) {
+	products, err := FindProducts(conn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading security hub products: %s", err)
+	}
+
+	result := make(map[string]string, len(products))
+	for _, product := range products {
+		productArn := aws.StringValue(product.ProductArn)
+		result[productKey(productArn)] = productArn
+	}
+
+	return result, nil
+}
+
+// findProductSubscriptionArnsByKey returns the ARNs of the subscriptions to the enabled products, by product key.
+func findProductSubscriptionArnsByKey(conn *securityhub.SecurityHub) (map[string]string, error) {
+	subscriptionArns, err := FindEnabledProductSubscriptionArns(conn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading enabled security hub products: %s", err)
+	}
+
+	result := make(map[string]string, len(subscriptionArns))
+	for _, subscriptionArn := range subscriptionArns {
+		result[productKey(subscriptionArn)] = subscriptionArn
+	}
+
+	return result, nil
+}
+
+// productKey returns the company and name of a product, e.g. aws/guardduty, from its name, its company and name, the
+// ARN of the product or the ARN of a subscription to it.
+func productKey(product string) string {
+	if strings.HasPrefix(product, "arn:") {
+		for _, prefix := range []string{productSubscriptionArnResourcePrefix, productArnResourcePrefix} {
+			if i := strings.Index(product, ":"+prefix); i >= 0 {
+				return product[i+len(prefix)+1:]
+			}
+		}
+
+		return product
+	}
+
+	if !strings.Contains(product, "/") {
+		return defaultProductCompany + "/" + product
+	}
+
+	return product
+}
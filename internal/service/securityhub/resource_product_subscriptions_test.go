@@ -0,0 +1,47 @@
+package securityhub
+
+import (
+	"testing"
+)
+
+func TestProductKey(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Product  string
+		Expected string
+	}{
+		{
+			Name:     "name",
+			Product:  "guardduty",
+			Expected: "aws/guardduty",
+		},
+		{
+			Name:     "company and name",
+			Product:  "crowdstrike/crowdstrike-falcon",
+			Expected: "crowdstrike/crowdstrike-falcon",
+		},
+		{
+			Name:     "product arn",
+			Product:  "arn:aws:securityhub:us-east-1::product/aws/access-analyzer",
+			Expected: "aws/access-analyzer",
+		},
+		{
+			Name:     "product subscription arn",
+			Product:  "arn:aws:securityhub:us-east-1:123456789012:product-subscription/aws/guardduty",
+			Expected: "aws/guardduty",
+		},
+		{
+			Name:     "third party product arn",
+			Product:  "arn:aws:securityhub:us-east-1:956882708938:product/crowdstrike/crowdstrike-falcon",
+			Expected: "crowdstrike/crowdstrike-falcon",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := productKey(testCase.Product); got != testCase.Expected {
+				t.Errorf("expected %q, got %q", testCase.Expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package securityhub
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/conns"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/flex"
+	"github.com/cloudposse/terraform-provider-awsutils/internal/tfresource"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// standardsSubscriptionTimeout is how long to wait for a standard to be enabled or disabled in an account.
+	standardsSubscriptionTimeout = 10 * time.Minute
+
+	defaultMemberRoleName = "OrganizationAccountAccessRole"
+)
+
+func ResourceSecurityHubStandardsSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		Description: `Enables a list of Security Hub standards, such as CIS, AWS Foundational Security Best Practices, PCI DSS or NIST,
+in a list of member accounts, from the Security Hub Administrator account.
+
+Security Hub only enables standards in the calling account, so the standards are enabled in each member account by
+assuming ` + "`member_role_name`" + ` in it. Accounts that are not Security Hub members yet are added as members first.
+The resource waits until each standard is ` + "`READY`" + `. Standards that are ` + "`FAILED`" + ` or being disabled in an account
+are enabled again by the next apply. The standards are disabled in each account on destroy.`,
+		Create:        resourceAwsSecurityHubStandardsSubscriptionsCreate,
+		Read:          resourceAwsSecurityHubStandardsSubscriptionsRead,
+		Update:        resourceAwsSecurityHubStandardsSubscriptionsUpdate,
+		Delete:        resourceAwsSecurityHubStandardsSubscriptionsDelete,
+		SchemaVersion: 1,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"account_standards": {
+				Description: "The status of each standard in each member account.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"standards_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"member_accounts": {
+				Description: "A list of AWS Organization member accounts to enable the standards in. " +
+					"It may include the Security Hub Administrator account itself.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Required: true,
+			},
+			"member_role_name": {
+				Description:  "The name of the IAM role to assume in each member account to enable the standards.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultMemberRoleName,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"standards_arns": {
+				Description: "A list of the ARNs of the standards to enable, e.g. " +
+					"`arn:aws:securityhub:us-east-1::standards/aws-foundational-security-best-practices/v/1.0.0`.",
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSecurityHubStandardsSubscriptionsCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	memberAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set)))
+	standardsArns := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("standards_arns").(*schema.Set)))
+	roleName := d.Get("member_role_name").(string)
+
+	if err := ensureSecurityHubOrganizationMembers(conn, meta.(*conns.AWSClient).AccountID, memberAccounts); err != nil {
+		return err
+	}
+
+	for _, accountID := range memberAccounts {
+		if err := updateAccountStandardsSubscriptions(meta, accountID, roleName, standardsArns, nil); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(uuid.New().String())
+
+	return resourceAwsSecurityHubStandardsSubscriptionsRead(d, meta)
+}
+
+func resourceAwsSecurityHubStandardsSubscriptionsRead(d *schema.ResourceData, meta interface{}) error {
+	memberAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set)))
+	standardsArns := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("standards_arns").(*schema.Set)))
+	roleName := d.Get("member_role_name").(string)
+
+	sort.Strings(memberAccounts)
+	sort.Strings(standardsArns)
+
+	var tfList []interface{}
+	// A standard that is no longer enabled in every account is removed from the state, so that it is enabled again.
+	enabledEverywhere := stringSet(standardsArns)
+
+	for _, accountID := range memberAccounts {
+		accountConn, err := memberSecurityHubConn(meta, accountID, roleName)
+		if err != nil {
+			return err
+		}
+
+		subscriptions, err := findStandardsSubscriptionsByArn(accountConn)
+		if err != nil {
+			return fmt.Errorf("error reading security hub standards of account %s: %s", accountID, err)
+		}
+
+		for _, standardsArn := range standardsArns {
+			subscription, ok := subscriptions[standardsArn]
+			if !ok {
+				log.Printf("[WARN] Security Hub standard (%s) no longer enabled in account %s", standardsArn, accountID)
+				delete(enabledEverywhere, standardsArn)
+				continue
+			}
+
+			if !isStandardsSubscriptionEnabled(subscription) {
+				log.Printf("[WARN] Security Hub standard (%s) is %s in account %s", standardsArn, aws.StringValue(subscription.StandardsStatus), accountID)
+				delete(enabledEverywhere, standardsArn)
+			}
+
+			var statusReason string
+			if subscription.StandardsStatusReason != nil {
+				statusReason = aws.StringValue(subscription.StandardsStatusReason.StatusReasonCode)
+			}
+
+			tfList = append(tfList, map[string]interface{}{
+				"account_id":    accountID,
+				"standards_arn": standardsArn,
+				"status":        aws.StringValue(subscription.StandardsStatus),
+				"status_reason": statusReason,
+			})
+		}
+	}
+
+	if err := d.Set("account_standards", tfList); err != nil {
+		return fmt.Errorf("error setting account_standards: %s", err)
+	}
+
+	var enabled []string
+	for standardsArn := range enabledEverywhere {
+		enabled = append(enabled, standardsArn)
+	}
+
+	if err := d.Set("standards_arns", enabled); err != nil {
+		return fmt.Errorf("error setting standards_arns: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAwsSecurityHubStandardsSubscriptionsUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*conns.AWSClient).SecurityHubConn
+	roleName := d.Get("member_role_name").(string)
+
+	o, n := d.GetChange("member_accounts")
+	oldAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(o.(*schema.Set)))
+	newAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(n.(*schema.Set)))
+
+	o, n = d.GetChange("standards_arns")
+	oldStandardsArns := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(o.(*schema.Set)))
+	newStandardsArns := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(n.(*schema.Set)))
+
+	if err := ensureSecurityHubOrganizationMembers(conn, meta.(*conns.AWSClient).AccountID, newAccounts); err != nil {
+		return err
+	}
+
+	for _, accountID := range newAccounts {
+		var previous []string
+		if stringSet(oldAccounts)[accountID] {
+			previous = oldStandardsArns
+		}
+
+		if err := updateAccountStandardsSubscriptions(meta, accountID, roleName, newStandardsArns, previous); err != nil {
+			return err
+		}
+	}
+
+	for _, accountID := range flex.Diff(oldAccounts, newAccounts) {
+		if err := updateAccountStandardsSubscriptions(meta, accountID, roleName, nil, oldStandardsArns); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsSecurityHubStandardsSubscriptionsRead(d, meta)
+}
+
+func resourceAwsSecurityHubStandardsSubscriptionsDelete(d *schema.ResourceData, meta interface{}) error {
+	memberAccounts := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("member_accounts").(*schema.Set)))
+	standardsArns := flex.ExpandStringSliceofPointers(flex.ExpandStringSet(d.Get("standards_arns").(*schema.Set)))
+	roleName := d.Get("member_role_name").(string)
+
+	for _, accountID := range memberAccounts {
+		if err := updateAccountStandardsSubscriptions(meta, accountID, roleName, nil, standardsArns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// memberSecurityHubConn returns a Security Hub client for the member account, assuming roleName in it. The client of
+// the provider is returned for the account of the provider.
+func memberSecurityHubConn(meta interface{}, accountID, roleName string) (*securityhub.SecurityHub, error) {
+	client := meta.(*conns.AWSClient)
+
+	if accountID == client.AccountID {
+		return client.SecurityHubConn, nil
+	}
+
+	roleArn := fmt.Sprintf("arn:%s:iam::%s:role/%s", client.Partition, accountID, roleName)
+	sess := client.Session.Copy(&aws.Config{
+		Credentials: stscreds.NewCredentials(client.Session, roleArn),
+	})
+
+	return securityhub.New(sess), nil
+}
+
+// ensureSecurityHubOrganizationMembers adds the accounts that are not Security Hub members yet as members.
+func ensureSecurityHubOrganizationMembers(conn *securityhub.SecurityHub, administratorAccountID string, accounts []string) error {
+	var candidates []string
+	for _, accountID := range accounts {
+		if accountID != administratorAccountID {
+			candidates = append(candidates, accountID)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	members := make(map[string]bool, len(candidates))
+
+	for _, batch := range chunkSecurityHubAccounts(candidates) {
+		output, err := conn.GetMembers(&securityhub.GetMembersInput{
+			AccountIds: makeAccountIDs(batch),
+		})
+		if err != nil {
+			return fmt.Errorf("error reading security hub administrator account members: %s", err)
+		}
+
+		for _, member := range output.Members {
+			members[aws.StringValue(member.AccountId)] = true
+		}
+	}
+
+	var missing []string
+	for _, accountID := range candidates {
+		if !members[accountID] {
+			missing = append(missing, accountID)
+		}
+	}
+
+	return addSecurityHubOrganizationMembers(conn, missing)
+}
+
+// updateAccountStandardsSubscriptions enables the wanted standards that are not enabled in the account yet, and
+// disables the previously enabled standards that are no longer wanted. It waits for the changes to complete.
+func updateAccountStandardsSubscriptions(meta interface{}, accountID, roleName string, wanted, previous []string) error {
+	conn, err := memberSecurityHubConn(meta, accountID, roleName)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := findStandardsSubscriptionsByArn(conn)
+	if err != nil {
+		return fmt.Errorf("error reading security hub standards of account %s: %s", accountID, err)
+	}
+
+	toEnable, pending, deleting := standardsSubscriptionsToEnable(subscriptions, wanted)
+
+	var toDisable []*string
+	var disabled []string
+	for _, standardsArn := range flex.Diff(previous, wanted) {
+		if subscription, ok := subscriptions[standardsArn]; ok {
+			disabled = append(disabled, standardsArn)
+
+			if aws.StringValue(subscription.StandardsStatus) != securityhub.StandardsStatusDeleting {
+				toDisable = append(toDisable, subscription.StandardsSubscriptionArn)
+			}
+		}
+	}
+
+	if len(deleting) > 0 {
+		// A standard that is still being disabled can only be enabled again once it is gone.
+		log.Printf("[DEBUG] Waiting for %d Security Hub standards to be disabled in account %s", len(deleting), accountID)
+
+		if err := waitStandardsSubscriptionsDeleted(conn, deleting); err != nil {
+			return fmt.Errorf("error waiting for security hub standards to be disabled in account %s: %s", accountID, err)
+		}
+	}
+
+	if len(toEnable) > 0 {
+		log.Printf("[DEBUG] Enabling %d Security Hub standards in account %s", len(toEnable), accountID)
+
+		var requests []*securityhub.StandardsSubscriptionRequest
+		for _, standardsArn := range toEnable {
+			requests = append(requests, &securityhub.StandardsSubscriptionRequest{
+				StandardsArn: aws.String(standardsArn),
+			})
+		}
+
+		if _, err := conn.BatchEnableStandards(&securityhub.BatchEnableStandardsInput{StandardsSubscriptionRequests: requests}); err != nil {
+			return fmt.Errorf("error enabling security hub standards in account %s: %s", accountID, err)
+		}
+	}
+
+	if len(toDisable) > 0 {
+		log.Printf("[DEBUG] Disabling %d Security Hub standards in account %s", len(toDisable), accountID)
+
+		if _, err := conn.BatchDisableStandards(&securityhub.BatchDisableStandardsInput{StandardsSubscriptionArns: toDisable}); err != nil {
+			return fmt.Errorf("error disabling security hub standards in account %s: %s", accountID, err)
+		}
+	}
+
+	if len(disabled) > 0 {
+		if err := waitStandardsSubscriptionsDeleted(conn, disabled); err != nil {
+			return fmt.Errorf("error waiting for security hub standards to be disabled in account %s: %s", accountID, err)
+		}
+	}
+
+	enabling := append(toEnable, pending...)
+
+	if len(enabling) == 0 {
+		return nil
+	}
+
+	err = tfresource.WaitUntil(standardsSubscriptionTimeout, func() (bool, error) {
+		subscriptions, err := findStandardsSubscriptionsByArn(conn)
+		if err != nil {
+			return false, err
+		}
+
+		for _, standardsArn := range enabling {
+			subscription, ok := subscriptions[standardsArn]
+			if !ok {
+				return false, nil
+			}
+
+			switch aws.StringValue(subscription.StandardsStatus) {
+			case securityhub.StandardsStatusFailed:
+				var reason string
+				if subscription.StandardsStatusReason != nil {
+					reason = aws.StringValue(subscription.StandardsStatusReason.StatusReasonCode)
+				}
+				return false, fmt.Errorf("standard %s failed to be enabled: %s", standardsArn, reason)
+			case securityhub.StandardsStatusReady:
+			default:
+				// INCOMPLETE is reported while some of the controls are still being enabled, so it is waited out
+				// along with PENDING.
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, tfresource.WaitOpts{
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	})
+
+	if err != nil {
+		return fmt.Errorf("error waiting for security hub standards in account %s: %s", accountID, err)
+	}
+
+	return nil
+}
+
+// standardsSubscriptionsToEnable sorts the wanted standards that are not enabled in the account. FAILED standards and
+// standards that are not subscribed are enabled, as are standards that are being disabled, once they are gone.
+// PENDING standards are already being enabled.
+func standardsSubscriptionsToEnable(subscriptions map[string]*securityhub.StandardsSubscription, wanted []string) (toEnable, pending, deleting []string) {
+	for _, standardsArn := range wanted {
+		subscription, ok := subscriptions[standardsArn]
+		if !ok {
+			toEnable = append(toEnable, standardsArn)
+			continue
+		}
+
+		switch aws.StringValue(subscription.StandardsStatus) {
+		case securityhub.StandardsStatusFailed:
+			toEnable = append(toEnable, standardsArn)
+		case securityhub.StandardsStatusDeleting:
+			toEnable = append(toEnable, standardsArn)
+			deleting = append(deleting, standardsArn)
+		case securityhub.StandardsStatusPending:
+			pending = append(pending, standardsArn)
+		}
+	}
+
+	return toEnable, pending, deleting
+}
+
+// isStandardsSubscriptionEnabled reports whether the standard is enabled in the account. INCOMPLETE standards are
+// enabled, though some of their controls are not.
+func isStandardsSubscriptionEnabled(subscription *securityhub.StandardsSubscription) bool {
+	switch aws.StringValue(subscription.StandardsStatus) {
+	case securityhub.StandardsStatusReady, securityhub.StandardsStatusIncomplete:
+		return true
+	}
+
+	return false
+}
+
+// waitStandardsSubscriptionsDeleted waits until the standards are no longer subscribed in the account.
+func waitStandardsSubscriptionsDeleted(conn *securityhub.SecurityHub, standardsArns []string) error {
+	return tfresource.WaitUntil(standardsSubscriptionTimeout, func() (bool, error) {
+		subscriptions, err := findStandardsSubscriptionsByArn(conn)
+		if err != nil {
+			return false, err
+		}
+
+		for _, standardsArn := range standardsArns {
+			if _, ok := subscriptions[standardsArn]; ok {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, tfresource.WaitOpts{
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	})
+}
+
+// findStandardsSubscriptionsByArn returns the standards subscribed in the account, whatever their status, by standards ARN.
+func findStandardsSubscriptionsByArn(conn *securityhub.SecurityHub) (map[string]*securityhub.StandardsSubscription, error) {
+	subscriptions, err := FindEnabledStandards(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*securityhub.StandardsSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result[aws.StringValue(subscription.StandardsArn)] = subscription
+	}
+
+	return result, nil
+}
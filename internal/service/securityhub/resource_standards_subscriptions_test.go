@@ -0,0 +1,56 @@
+package securityhub
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/securityhub"
+)
+
+func TestStandardsSubscriptionsToEnable(t *testing.T) {
+	subscriptions := map[string]*securityhub.StandardsSubscription{
+		"ready":      {StandardsStatus: aws.String(securityhub.StandardsStatusReady)},
+		"incomplete": {StandardsStatus: aws.String(securityhub.StandardsStatusIncomplete)},
+		"pending":    {StandardsStatus: aws.String(securityhub.StandardsStatusPending)},
+		"failed":     {StandardsStatus: aws.String(securityhub.StandardsStatusFailed)},
+		"deleting":   {StandardsStatus: aws.String(securityhub.StandardsStatusDeleting)},
+	}
+
+	toEnable, pending, deleting := standardsSubscriptionsToEnable(subscriptions, []string{"ready", "incomplete", "pending", "failed", "deleting", "missing"})
+
+	if expected := []string{"failed", "deleting", "missing"}; !reflect.DeepEqual(toEnable, expected) {
+		t.Errorf("expected to enable %v, got %v", expected, toEnable)
+	}
+
+	if expected := []string{"pending"}; !reflect.DeepEqual(pending, expected) {
+		t.Errorf("expected pending %v, got %v", expected, pending)
+	}
+
+	if expected := []string{"deleting"}; !reflect.DeepEqual(deleting, expected) {
+		t.Errorf("expected deleting %v, got %v", expected, deleting)
+	}
+}
+
+func TestIsStandardsSubscriptionEnabled(t *testing.T) {
+	testCases := []struct {
+		Status   string
+		Expected bool
+	}{
+		{Status: securityhub.StandardsStatusReady, Expected: true},
+		{Status: securityhub.StandardsStatusIncomplete, Expected: true},
+		{Status: securityhub.StandardsStatusPending},
+		{Status: securityhub.StandardsStatusFailed},
+		{Status: securityhub.StandardsStatusDeleting},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Status, func(t *testing.T) {
+			subscription := &securityhub.StandardsSubscription{StandardsStatus: aws.String(testCase.Status)}
+
+			if got := isStandardsSubscriptionEnabled(subscription); got != testCase.Expected {
+				t.Errorf("expected %t, got %t", testCase.Expected, got)
+			}
+		})
+	}
+}